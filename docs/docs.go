@@ -158,8 +158,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/login": {
+        "/admin/geofences": {
             "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Circles need center_lat, center_lon and radius_km; polygons need a ring of [lon, lat] points",
                 "consumes": [
                     "application/json"
                 ],
@@ -167,25 +173,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Geofences (Admin)"
                 ],
-                "summary": "Admin Login",
+                "summary": "Create Geofence",
                 "parameters": [
                     {
-                        "description": "Admin credentials",
-                        "name": "credentials",
+                        "description": "Geofence data",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
+                            "$ref": "#/definitions/handlers.GeofenceRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
+                            "$ref": "#/definitions/models.Geofence"
                         }
                     },
                     "400": {
@@ -194,8 +200,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -203,8 +215,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/register": {
-            "post": {
+        "/admin/geofences/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -212,86 +229,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Geofences (Admin)"
                 ],
-                "summary": "Register Admin",
+                "summary": "Update Geofence",
                 "parameters": [
                     {
-                        "description": "Admin credentials",
-                        "name": "credentials",
+                        "type": "integer",
+                        "description": "Geofence ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Geofence data",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handlers.GeofenceRequest"
                         }
                     }
-                }
-            }
-        },
-        "/blog/posts": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Blog"
                 ],
-                "summary": "Get All Posts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Post"
-                            }
+                            "$ref": "#/definitions/models.Geofence"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/blog/posts/{id}": {
-            "get": {
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
                 "tags": [
-                    "Blog"
+                    "Geofences (Admin)"
                 ],
-                "summary": "Get Post by ID",
+                "summary": "Delete Geofence",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Post ID",
+                        "description": "Geofence ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.Post"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/health": {
-            "get": {
-                "description": "Returns the health status of the database and application",
+        "/admin/iss/events": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -299,37 +317,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "Events (Admin)"
                 ],
-                "summary": "Health Check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                "summary": "Create Mission Event",
+                "parameters": [
+                    {
+                        "description": "Event data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/handlers.MissionEventRequest"
                         }
                     }
-                }
-            }
-        },
-        "/iss/crew": {
-            "get": {
-                "description": "Return current crew aboard the ISS",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Crew"
                 ],
-                "summary": "Get current ISS crew",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ISSCrewResponse"
+                            "$ref": "#/definitions/models.MissionEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -341,9 +353,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/iss/current": {
-            "get": {
-                "description": "Returns the current position of the International Space Station",
+        "/admin/iss/events/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -351,27 +367,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ISS"
+                    "Events (Admin)"
                 ],
-                "summary": "Get Current ISS Position",
+                "summary": "Update Mission Event",
                 "parameters": [
                     {
-                        "enum": [
-                            "kilometers",
-                            "miles"
-                        ],
-                        "type": "string",
-                        "default": "kilometers",
-                        "description": "Units (kilometers or miles)",
-                        "name": "units",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Event data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MissionEventRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ISSPosition"
+                            "$ref": "#/definitions/models.MissionEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -381,11 +408,40 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Events (Admin)"
+                ],
+                "summary": "Delete Mission Event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/iss/historical": {
+        "/admin/iss/modules": {
             "post": {
-                "description": "Returns the ISS position for a timestamp provided in request body",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -393,25 +449,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ISS"
+                    "Modules (Admin)"
                 ],
-                "summary": "Get Historical ISS Position (POST)",
+                "summary": "Create Module",
                 "parameters": [
                     {
-                        "description": "Historical position request",
+                        "description": "Module data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.HistoricalRequest"
+                            "$ref": "#/definitions/handlers.ModuleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.ISSPosition"
+                            "$ref": "#/definitions/models.Module"
                         }
                     },
                     "400": {
@@ -420,8 +476,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -435,9 +491,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/iss/historical/{timestamp}": {
-            "get": {
-                "description": "Returns the ISS position for a specific timestamp (within 4 hours back/forward)",
+        "/admin/iss/modules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -445,34 +505,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ISS"
+                    "Modules (Admin)"
                 ],
-                "summary": "Get Historical ISS Position",
+                "summary": "Update Module",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Unix timestamp",
-                        "name": "timestamp",
+                        "description": "Module ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "kilometers",
-                            "miles"
-                        ],
-                        "type": "string",
-                        "default": "kilometers",
-                        "description": "Units (kilometers or miles)",
-                        "name": "units",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
+                        "description": "Module data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ModuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ISSPosition"
+                            "$ref": "#/definitions/models.Module"
                         }
                     },
                     "400": {
@@ -481,8 +539,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -494,45 +552,64 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Modules (Admin)"
+                ],
+                "summary": "Delete Module",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Module ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/iss/range": {
+        "/admin/iss/quarantine": {
             "get": {
-                "description": "Returns all ISS positions within a specified time range",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Lists upstream samples the collector refused to store because their altitude, velocity, timestamp or implied motion was physically impossible, newest first, with the failed rule and the reason",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ISS"
+                    "ISS (Admin)"
                 ],
-                "summary": "Get ISS Positions in Time Range",
+                "summary": "Get Quarantined Positions",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Start timestamp (Unix)",
-                        "name": "start_time",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "End timestamp (Unix)",
-                        "name": "end_time",
-                        "in": "query",
-                        "required": true
-                    },
                     {
                         "enum": [
-                            "kilometers",
-                            "miles"
+                            "pending",
+                            "approved",
+                            "rejected"
                         ],
                         "type": "string",
-                        "default": "kilometers",
-                        "description": "Units (kilometers or miles)",
-                        "name": "units",
+                        "description": "Only samples with this review status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of samples (default 100, max 1000)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -542,7 +619,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.ISSPosition"
+                                "$ref": "#/definitions/models.QuarantinedPosition"
                             }
                         }
                     },
@@ -561,24 +638,111 @@ const docTemplate = `{
                 }
             }
         },
-        "/iss/solar-angle": {
-            "get": {
-                "description": "Returns the calculated azimuth angle of the sun relative to the ISS (for motor control)",
-                "consumes": [
+        "/admin/iss/quarantine/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks a pending sample as genuine and adds it to the stored positions",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "ISS (Admin)"
+                ],
+                "summary": "Approve Quarantined Position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quarantined position ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuarantinedPosition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/iss/quarantine/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Marks a pending sample as bogus; it stays in quarantine for the record",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "ISS"
+                    "ISS (Admin)"
+                ],
+                "summary": "Reject Quarantined Position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quarantined position ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Solar Azimuth Angle",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SolarAngleResponse"
+                            "$ref": "#/definitions/models.QuarantinedPosition"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -590,9 +754,13 @@ const docTemplate = `{
                 }
             }
         },
-        "/iss/status": {
-            "get": {
-                "description": "Returns statistics about ISS tracking data and system status",
+        "/admin/iss/vehicles": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -600,15 +768,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ISS"
+                    "Vehicles (Admin)"
+                ],
+                "summary": "Create Visiting Vehicle",
+                "parameters": [
+                    {
+                        "description": "Vehicle data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VehicleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.VisitingVehicle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/iss/vehicles/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles (Admin)"
+                ],
+                "summary": "Update Visiting Vehicle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vehicle data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.VehicleRequest"
+                        }
+                    }
                 ],
-                "summary": "Get ISS Tracking Status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.VisitingVehicle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
@@ -618,258 +859,4868 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "tags": [
+                    "Vehicles (Admin)"
+                ],
+                "summary": "Delete Visiting Vehicle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
-        }
-    },
-    "definitions": {
-        "handlers.CreatePostRequest": {
-            "type": "object",
-            "properties": {
+        },
+        "/admin/login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Admin Login",
+                "parameters": [
+                    {
+                        "description": "Admin credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register Admin",
+                "parameters": [
+                    {
+                        "description": "Admin credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/satellites": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a satellite to the catalog. Active satellites (the default) are collected every collection_interval seconds (default 60, min 10). The position API is asked for the satellite first, and satellites it doesn't serve are rejected",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites (Admin)"
+                ],
+                "summary": "Create Satellite",
+                "parameters": [
+                    {
+                        "description": "Satellite data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SatelliteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Satellite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/satellites/{norad}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Renames a satellite or changes its collection schedule. The ISS can't be deactivated",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites (Admin)"
+                ],
+                "summary": "Update Satellite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Satellite data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SatelliteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Satellite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a satellite from the catalog and stops collecting it. The ISS can't be removed",
+                "tags": [
+                    "Satellites (Admin)"
+                ],
+                "summary": "Delete Satellite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Get All Webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookSubscription"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Registers an endpoint for the given event types. Deliveries are signed with HMAC-SHA256 over \"\u003cX-Webhook-Timestamp\u003e.\u003cbody\u003e\" and sent in the X-Webhook-Signature header. A secret is generated when none is given; it is only returned in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Create Webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscriptionWithSecret"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/dead-letters": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns deliveries that failed after all retry attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Get Dead-Lettered Deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of deliveries (max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/deliveries/{id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Retry Dead-Lettered Delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the webhook settings. An empty secret keeps the current one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Update Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookSubscription"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes the webhook together with its delivery log",
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Delete Webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks (Admin)"
+                ],
+                "summary": "Get Webhook Delivery Log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "dead"
+                        ],
+                        "type": "string",
+                        "description": "Delivery status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of deliveries (max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.WebhookDelivery"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/posts": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog"
+                ],
+                "summary": "Get All Posts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Post"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/blog/posts/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Blog"
+                ],
+                "summary": "Get Post by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Post ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Post"
+                        }
+                    }
+                }
+            }
+        },
+        "/earth/terminator": {
+            "get": {
+                "description": "Returns a GeoJSON FeatureCollection with the solar subpoint, the civil (0° to -6° Sun elevation), nautical (-6° to -12°) and astronomical (-12° to -18°) twilight bands and the night region below -18°. Each band is a ring between two elevation limits, given as a polygon with a hole where needed, so bands can be styled separately. Polygons crossing the antimeridian are split into MultiPolygons.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Earth"
+                ],
+                "summary": "Get Day/Night Terminator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instant as Unix seconds or RFC 3339 (default: now)",
+                        "name": "time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoJSONFeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the database and application, with per-host counters of outbound API calls (cache hits, retries, rate limiting, circuit breaker state) under \"upstream\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/altitude/forecast": {
+            "get": {
+                "description": "Extrapolates the decay trend one point a day with a 95% prediction interval. The linear model knows nothing of solar activity or upcoming reboosts, so it is only meaningful for the next few weeks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Altitude"
+                ],
+                "summary": "Get Altitude Forecast",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days to forecast (default 28, max 90)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of history to fit (default 14, max 365)",
+                        "name": "trend_days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AltitudeForecastResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/altitude/trend": {
+            "get": {
+                "description": "Fits the orbital decay rate to the mean altitude of each complete revolution, collected from ascending node to ascending node and kept after the raw positions expire. The fit starts after the last reboost on the mission timeline; reboosts are detected automatically as step changes and logged there with their estimated delta-v.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Altitude"
+                ],
+                "summary": "Get Altitude Decay Trend",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days of history to analyze (default 14, max 365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AltitudeTrendResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/calendar.ics": {
+            "get": {
+                "description": "iCalendar feed of the visible ISS passes over an observer plus visiting vehicle dockings and undockings and the curated mission events (spacewalks, reboosts, launches...), meant to be subscribed to from Google Calendar or any other calendar app. Every event keeps a stable UID so refreshes update entries instead of duplicating them. Pass times come from the same propagation as /iss/passes.",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get Calendar Feed",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Observer latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer longitude",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer altitude in meters (default 0)",
+                        "name": "alt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Prediction horizon in hours (default 72, max 72)",
+                        "name": "hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only list passes reaching this elevation in degrees (default 10)",
+                        "name": "min_elevation",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/coverage": {
+            "get": {
+                "description": "Returns the ground-track density accumulated by the collector as a GeoJSON grid: one polygon per visited cell with the seconds spent there and a log-scaled density in [0, 1]",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coverage"
+                ],
+                "summary": "Get Coverage Grid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First UTC day (YYYY-MM-DD, default: 29 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last UTC day (YYYY-MM-DD, default: today)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 5,
+                        "description": "Cell size in degrees, must divide 180",
+                        "name": "cell_deg",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoJSONFeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/coverage/{z}/{x}/{y}.png": {
+            "get": {
+                "description": "Renders the ground-track density as a transparent 256x256 PNG map tile in the XYZ (Web Mercator) scheme, for use as an overlay in Leaflet, MapLibre or OpenLayers. Colours are scaled to the busiest cell of the whole time window, so tiles match at their edges.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Coverage"
+                ],
+                "summary": "Get Coverage Tile",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Zoom level (0-10)",
+                        "name": "z",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tile column",
+                        "name": "x",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tile row",
+                        "name": "y",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First UTC day (YYYY-MM-DD, default: 29 days before to)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last UTC day (YYYY-MM-DD, default: today)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/crew": {
+            "get": {
+                "description": "Return current crew aboard the ISS",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Crew"
+                ],
+                "summary": "Get current ISS crew",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSCrewResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/current": {
+            "get": {
+                "description": "Returns the current position of the International Space Station",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Current ISS Position",
+                "parameters": [
+                    {
+                        "enum": [
+                            "kilometers",
+                            "miles"
+                        ],
+                        "type": "string",
+                        "default": "kilometers",
+                        "description": "Units (kilometers or miles)",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ecef",
+                            "eci",
+                            "teme",
+                            "j2000"
+                        ],
+                        "type": "string",
+                        "description": "Add a Cartesian state vector in this frame (eci is an alias of j2000)",
+                        "name": "frame",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSPositionWithState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/events": {
+            "get": {
+                "description": "Returns the curated mission timeline (spacewalks, dockings, reboosts, launches, experiments) in chronological order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get Mission Events",
+                "parameters": [
+                    {
+                        "enum": [
+                            "eva",
+                            "docking",
+                            "undocking",
+                            "reboost",
+                            "launch",
+                            "experiment"
+                        ],
+                        "type": "string",
+                        "description": "Only events of this type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events ending at or after this Unix timestamp",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events starting at or before this Unix timestamp",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events in progress at this Unix timestamp",
+                        "name": "timestamp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events involving this crew member",
+                        "name": "crew",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events related to this module",
+                        "name": "module_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events related to this visiting vehicle",
+                        "name": "vehicle_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events (default 100, max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MissionEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/events/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get Mission Event by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MissionEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/geofences": {
+            "get": {
+                "description": "Returns all defined geofences",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "Get All Geofences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Geofence"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/geofences/events": {
+            "get": {
+                "description": "Returns enter and exit events recorded by the collector, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Geofences"
+                ],
+                "summary": "Get Geofence Events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only events of this geofence",
+                        "name": "geofence_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start timestamp (Unix)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End timestamp (Unix)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of events (max 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.GeofenceEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/ground-track": {
+            "get": {
+                "description": "Returns stored positions in a time range as GeoJSON LineStrings split at the antimeridian and at SAA boundary crossings (property in_saa), plus the SAA boundary as a shaded polygon",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get ISS Ground Track",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start timestamp (Unix)",
+                        "name": "start_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End timestamp (Unix)",
+                        "name": "end_time",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoJSONFeatureCollection"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/historical": {
+            "post": {
+                "description": "Returns the ISS position for a timestamp provided in request body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Historical ISS Position (POST)",
+                "parameters": [
+                    {
+                        "description": "Historical position request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSPositionWithState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/historical/batch": {
+            "post": {
+                "description": "Returns the ISS positions for up to 500 timestamps in request order, e.g. the keyframes of a whole orbit. Each comes from a stored sample, is interpolated between stored samples, or is fetched from wheretheiss.at in paced batches of 10; source says which",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Historical ISS Positions in Batch",
+                "parameters": [
+                    {
+                        "description": "Historical batch request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricalBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricalBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/historical/{timestamp}": {
+            "get": {
+                "description": "Returns the ISS position for a specific timestamp (within 4 hours back/forward)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Historical ISS Position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kilometers",
+                            "miles"
+                        ],
+                        "type": "string",
+                        "default": "kilometers",
+                        "description": "Units (kilometers or miles)",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ecef",
+                            "eci",
+                            "teme",
+                            "j2000"
+                        ],
+                        "type": "string",
+                        "description": "Add a Cartesian state vector in this frame (eci is an alias of j2000)",
+                        "name": "frame",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSPositionWithState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/landmarks": {
+            "get": {
+                "description": "Returns the embedded landmarks (monuments, mountains, spaceports, natural features) with their ids for use with /iss/landmarks/upcoming",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "List Landmarks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Landmark"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/landmarks/upcoming": {
+            "get": {
+                "description": "Predicts passes of the ISS sub-satellite point within radius_km of the selected landmarks over the next hours by propagating the current orbit (two-body + J2, no drag). Each pass reports its closest approach and the bearing from the ISS to the landmark at that moment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Upcoming Landmark Overflights",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Prediction horizon in hours (default 12, max 24)",
+                        "name": "hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated landmark ids (default: all)",
+                        "name": "landmarks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius around each landmark in km (default 150, max 1000)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.LandmarkOverflightsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/live": {
+            "get": {
+                "description": "Upgrades to a WebSocket and pushes every live event as JSON: {\"type\": ..., \"timestamp\": ..., \"data\": ...}. Event types: position, orbit, geofence, crew_change, post_published.\nWith mode=replay the session re-emits stored positions between start and end, with their original spacing divided by speed, plus the orbit events between them. A final replay_end event is sent before the server closes the connection.\nWith sim_start or sim_speed the session runs on its own simulated clock instead: every 2 seconds it pushes the position at simulated time, plus orbit events derived from consecutive simulated positions.",
+                "tags": [
+                    "Stream"
+                ],
+                "summary": "Live Event Stream (WebSocket)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated list of event types to receive (default: all)",
+                        "name": "types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "live",
+                            "replay"
+                        ],
+                        "type": "string",
+                        "description": "live (default) or replay",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Replay: first stored timestamp to emit (Unix)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Replay: last stored timestamp to emit (Unix)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Replay: playback speed multiplier (default 1, max 3600)",
+                        "name": "speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/model/attitude": {
+            "get": {
+                "description": "Returns the nominal LVLH (+XVV) attitude as a quaternion rotating body-frame vectors into ECEF, plus the nadir, velocity and sun unit vectors in the body frame (X along velocity, Y opposite the orbit normal, Z towards nadir)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS Model"
+                ],
+                "summary": "Get ISS Attitude",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp (default: now)",
+                        "name": "timestamp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AttitudeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/model/gimbals": {
+            "get": {
+                "description": "Returns the nominal sun-tracking angles of the solar alpha rotary joints (SARJ), beta gimbal assemblies (BGA) and radiator rotary joints (TRRJ) in degrees. SARJ alpha is a rotation about body +Y with 0° facing zenith; BGA beta tilts the arrays towards +Y.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS Model"
+                ],
+                "summary": "Get ISS Gimbal Angles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp (default: now)",
+                        "name": "timestamp",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GimbalAnglesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/modules": {
+            "get": {
+                "description": "Returns the ISS module catalog ordered by launch date",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modules"
+                ],
+                "summary": "Get All Modules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Module"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/modules/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Modules"
+                ],
+                "summary": "Get Module by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Module ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Module"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/overflights": {
+            "get": {
+                "description": "Ranks countries, oceans and seas by the time the ISS spent over them, accumulated by the collector. Regions come from offline reverse geocoding against embedded land and sea outlines, with the nearest embedded city or capital picking the country, so time near borders and coasts is approximate.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overflights"
+                ],
+                "summary": "Get Overflight Ranking",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "today (UTC), the last 7 days or all time",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "country",
+                            "ocean",
+                            "sea"
+                        ],
+                        "type": "string",
+                        "description": "Only list this kind of region",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of regions (default: all)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OverflightRankingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/overflights/{code}": {
+            "get": {
+                "description": "Returns the daily time the ISS spent over one region (ISO country code such as PL, or an ocean/sea code such as atlantic_ocean), with days without overflights as zero",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overflights"
+                ],
+                "summary": "Get Region Overflight Series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Region code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Number of UTC days ending today (max 366)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.OverflightSeriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/passes": {
+            "get": {
+                "description": "Predicts passes of the ISS above an observer's horizon over the next hours by propagating the current orbit (two-body + J2, no drag). Each pass has its AOS, TCA and LOS, the sampled path across the sky with sunlit/eclipsed flags, whether it is visible to the naked eye (ISS sunlit, observer's sky dark) and the URL of its SVG sky plot.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Upcoming Passes",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Observer latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer longitude",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer altitude in meters (default 0)",
+                        "name": "alt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Prediction horizon in hours (default 24, max 72)",
+                        "name": "hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only list passes reaching this elevation in degrees (default 10)",
+                        "name": "min_elevation",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ObserverPassesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/passes/{aos}/sky.svg": {
+            "get": {
+                "description": "Draws a pass listed by /iss/passes as a printable SVG polar plot of azimuth and elevation, with AOS, TCA and LOS marked and the sunlit and eclipsed parts of the path told apart. Times are UTC.",
+                "produces": [
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Pass Sky Plot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "AOS of the pass (Unix timestamp, as listed by /iss/passes)",
+                        "name": "aos",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer longitude",
+                        "name": "lon",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Observer altitude in meters (default 0)",
+                        "name": "alt",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SVG document",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/ports/occupancy": {
+            "get": {
+                "description": "Returns every docking port from the module catalog with the vehicle attached to it at the given time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get Docking Port Occupancy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp (default: now)",
+                        "name": "timestamp",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PortOccupancyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/range": {
+            "get": {
+                "description": "Returns all ISS positions within a specified time range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get ISS Positions in Time Range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start timestamp (Unix)",
+                        "name": "start_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End timestamp (Unix)",
+                        "name": "end_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kilometers",
+                            "miles"
+                        ],
+                        "type": "string",
+                        "default": "kilometers",
+                        "description": "Units (kilometers or miles)",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ecef",
+                            "eci",
+                            "teme",
+                            "j2000"
+                        ],
+                        "type": "string",
+                        "description": "Add a Cartesian state vector in this frame (eci is an alias of j2000)",
+                        "name": "frame",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ISSPositionWithState"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/saa": {
+            "get": {
+                "description": "Returns whether the ISS is inside the South Atlantic Anomaly and predicts its SAA passes over the next hours by propagating the current orbit (two-body + J2, no drag)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get SAA Status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Prediction horizon in hours (default 6, max 24)",
+                        "name": "hours",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SAAStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/saa/boundary": {
+            "get": {
+                "description": "Returns the embedded South Atlantic Anomaly boundary as a GeoJSON FeatureCollection with simplestyle fill properties, for use as a map overlay",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get SAA Boundary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GeoJSONFeatureCollection"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/snapshot.png": {
+            "get": {
+                "description": "Renders an equirectangular world map as PNG with the ISS, its visibility footprint, the last orbit of stored ground track and day/night shading. Everything is drawn server-side from embedded coastlines, so the image can be used directly in link previews.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Map Snapshot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp (default: now)",
+                        "name": "time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1024,
+                        "description": "Image width in pixels (256-2048)",
+                        "name": "width",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Image height in pixels (128-1024, default: width/2)",
+                        "name": "height",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/snapshot/card.png": {
+            "get": {
+                "description": "Renders a 1200x630 PNG for Open Graph and Discord link previews: the snapshot map centered on the ISS with a caption naming the country or ocean below it, its coordinates, altitude and the time",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Social Card",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp (default: now)",
+                        "name": "time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/solar-angle": {
+            "get": {
+                "description": "Returns the calculated azimuth angle of the sun relative to the ISS (for motor control)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get Solar Azimuth Angle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SolarAngleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/status": {
+            "get": {
+                "description": "Returns statistics about ISS tracking data and system status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ISS"
+                ],
+                "summary": "Get ISS Tracking Status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simulate time starting at this Unix timestamp",
+                        "name": "sim_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Simulation speed multiplier (default 1, max 3600)",
+                        "name": "sim_speed",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/vehicles": {
+            "get": {
+                "description": "Returns visiting vehicles, most recent arrival first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get Visiting Vehicles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only vehicles attached at this Unix timestamp",
+                        "name": "timestamp",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.VisitingVehicle"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/iss/vehicles/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Vehicles"
+                ],
+                "summary": "Get Visiting Vehicle by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VisitingVehicle"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites": {
+            "get": {
+                "description": "Returns the satellite catalog ordered by NORAD catalog number",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Satellites",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Satellite"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Satellite",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Satellite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}/current": {
+            "get": {
+                "description": "Returns the current position of a satellite in the catalog, like /iss/current does for the ISS",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Current Satellite Position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kilometers",
+                            "miles"
+                        ],
+                        "type": "string",
+                        "default": "kilometers",
+                        "description": "Units (kilometers or miles)",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ecef",
+                            "eci",
+                            "teme",
+                            "j2000"
+                        ],
+                        "type": "string",
+                        "description": "Add a Cartesian state vector in this frame (eci is an alias of j2000)",
+                        "name": "frame",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSPositionWithState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}/historical": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Historical Satellite Position (POST)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Historical position request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSPositionWithState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}/historical/batch": {
+            "post": {
+                "description": "Returns a satellite's positions for up to 500 timestamps, like /iss/historical/batch does for the ISS",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Historical Satellite Positions in Batch",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Historical batch request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricalBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HistoricalBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}/historical/{timestamp}": {
+            "get": {
+                "description": "Returns a satellite's position for a specific timestamp (within 4 hours back/forward)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Historical Satellite Position",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp",
+                        "name": "timestamp",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kilometers",
+                            "miles"
+                        ],
+                        "type": "string",
+                        "default": "kilometers",
+                        "description": "Units (kilometers or miles)",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ecef",
+                            "eci",
+                            "teme",
+                            "j2000"
+                        ],
+                        "type": "string",
+                        "description": "Add a Cartesian state vector in this frame (eci is an alias of j2000)",
+                        "name": "frame",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ISSPositionWithState"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}/range": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Satellite Positions in Time Range",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start timestamp (Unix)",
+                        "name": "start_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End timestamp (Unix)",
+                        "name": "end_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "kilometers",
+                            "miles"
+                        ],
+                        "type": "string",
+                        "default": "kilometers",
+                        "description": "Units (kilometers or miles)",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ecef",
+                            "eci",
+                            "teme",
+                            "j2000"
+                        ],
+                        "type": "string",
+                        "description": "Add a Cartesian state vector in this frame (eci is an alias of j2000)",
+                        "name": "frame",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ISSPositionWithState"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/satellites/{norad}/status": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Satellites"
+                ],
+                "summary": "Get Satellite Tracking Status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "NORAD catalog number",
+                        "name": "norad",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/utils/time": {
+            "get": {
+                "description": "Reads an instant on one time scale and returns it as UTC, Unix, TAI, TT, GPS (seconds, week and seconds of week), Julian dates and Greenwich mean/apparent sidereal time. Leap seconds come from a built-in table.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Utils"
+                ],
+                "summary": "Convert Time Scales",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instant to convert (default: now). RFC 3339 or Unix seconds for utc, YYYY-MM-DDThh:mm:ss for tai/tt, seconds since 1980-01-06 for gps, days for jd/mjd/jd_tt",
+                        "name": "value",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "utc",
+                            "unix",
+                            "tai",
+                            "tt",
+                            "gps",
+                            "jd",
+                            "mjd",
+                            "jd_tt"
+                        ],
+                        "type": "string",
+                        "default": "utc",
+                        "description": "Scale of value",
+                        "name": "scale",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeConversionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.CreatePostRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "excerpt": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publishDate": {
+                    "type": "string"
+                },
+                "readTime": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GeofenceRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "center_lat": {
+                    "type": "number"
+                },
+                "center_lon": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "radius_km": {
+                    "type": "number"
+                },
+                "shape": {
+                    "type": "string",
+                    "enum": [
+                        "circle",
+                        "polygon"
+                    ]
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.MissionEventRequest": {
+            "type": "object",
+            "properties": {
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "delta_v_ms": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "module_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "US EVA 91"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "eva",
+                        "docking",
+                        "undocking",
+                        "reboost",
+                        "launch",
+                        "experiment"
+                    ]
+                },
+                "vehicle_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handlers.ModuleRequest": {
+            "type": "object",
+            "properties": {
+                "agency": {
+                    "type": "string"
+                },
+                "attachment_port": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/models.Dimensions"
+                },
+                "docking_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "launch_date": {
+                    "type": "string",
+                    "example": "1998-11-20"
+                },
+                "mass_kg": {
+                    "type": "number"
+                },
+                "model_asset_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "transform": {
+                    "$ref": "#/definitions/models.Transform"
+                }
+            }
+        },
+        "handlers.RegisterRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.SatelliteRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "collection_interval": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "name": {
+                    "type": "string",
+                    "example": "CSS (TIANHE)"
+                },
+                "norad_id": {
+                    "type": "integer",
+                    "example": 48274
+                }
+            }
+        },
+        "handlers.VehicleRequest": {
+            "type": "object",
+            "properties": {
+                "arrival_at": {
+                    "type": "string"
+                },
+                "departure_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "model_asset_url": {
+                    "type": "string"
+                },
+                "module_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Crew-9 Freedom"
+                },
+                "port": {
+                    "type": "string",
+                    "example": "zenith"
+                },
+                "vehicle_type": {
+                    "type": "string",
+                    "enum": [
+                        "dragon",
+                        "soyuz",
+                        "progress",
+                        "cygnus",
+                        "starliner",
+                        "htv",
+                        "dream_chaser",
+                        "other"
+                    ]
+                }
+            }
+        },
+        "handlers.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "position",
+                            "orbit",
+                            "geofence",
+                            "crew_change",
+                            "post_published"
+                        ]
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AltitudeForecastPoint": {
+            "type": "object",
+            "properties": {
+                "altitude_km": {
+                    "type": "number"
+                },
+                "lower_km": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "upper_km": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.AltitudeForecastResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "decay_m_per_day": {
+                    "type": "number"
+                },
+                "decay_std_err_m_per_day": {
+                    "type": "number"
+                },
+                "fit_from": {
+                    "type": "integer"
+                },
+                "orbits": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AltitudeForecastPoint"
+                    }
+                }
+            }
+        },
+        "models.AltitudeTrendResponse": {
+            "type": "object",
+            "properties": {
+                "current_altitude_km": {
+                    "type": "number"
+                },
+                "decay_m_per_day": {
+                    "type": "number"
+                },
+                "decay_std_err_m_per_day": {
+                    "type": "number"
+                },
+                "fit_from": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "orbits": {
+                    "type": "integer"
+                },
+                "reboosts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MissionEvent"
+                    }
+                },
+                "residual_std_dev_m": {
+                    "type": "number"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrbitAltitude"
+                    }
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Astronaut": {
+            "type": "object",
+            "properties": {
+                "craft": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AstronautWithPhoto": {
+            "type": "object",
+            "properties": {
+                "imgUrl": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AttitudeResponse": {
+            "type": "object",
+            "properties": {
+                "altitude": {
+                    "type": "number"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "nadir_body": {
+                    "$ref": "#/definitions/models.Vector3"
+                },
+                "quaternion": {
+                    "$ref": "#/definitions/models.Quaternion"
+                },
+                "reference_frame": {
+                    "type": "string"
+                },
+                "sun_body": {
+                    "$ref": "#/definitions/models.Vector3"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "velocity_body": {
+                    "$ref": "#/definitions/models.Vector3"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Dimensions": {
+            "type": "object",
+            "properties": {
+                "height_m": {
+                    "type": "number"
+                },
+                "length_m": {
+                    "type": "number"
+                },
+                "width_m": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeoJSONFeature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/models.GeoJSONGeometry"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeoJSONFeatureCollection": {
+            "type": "object",
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GeoJSONFeature"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeoJSONGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Geofence": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "center_lat": {
+                    "type": "number"
+                },
+                "center_lon": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number",
+                            "format": "float64"
+                        }
+                    }
+                },
+                "radius_km": {
+                    "type": "number"
+                },
+                "shape": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GeofenceEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "geofence_id": {
+                    "type": "integer"
+                },
+                "geofence_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GimbalAnglesResponse": {
+            "type": "object",
+            "properties": {
+                "bga_beta": {
+                    "type": "number"
+                },
+                "sarj_alpha": {
+                    "type": "number"
+                },
+                "solar_beta_angle": {
+                    "type": "number"
+                },
+                "sun_body": {
+                    "$ref": "#/definitions/models.Vector3"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "trrj": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HistoricalBatchPosition": {
+            "type": "object",
+            "properties": {
+                "altitude": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daynum": {
+                    "type": "number"
+                },
+                "footprint": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_saa": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nearest_city": {
+                    "$ref": "#/definitions/models.NearbyPlace"
+                },
+                "satellite_id": {
+                    "type": "integer"
+                },
+                "solar_lat": {
+                    "type": "number"
+                },
+                "solar_lon": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string",
+                    "enum": [
+                        "stored",
+                        "interpolated",
+                        "upstream"
+                    ]
+                },
+                "state": {
+                    "$ref": "#/definitions/models.StateVector"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "velocity": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HistoricalBatchRequest": {
+            "type": "object",
+            "required": [
+                "timestamps"
+            ],
+            "properties": {
+                "frame": {
+                    "type": "string",
+                    "enum": [
+                        "ecef",
+                        "eci",
+                        "teme",
+                        "j2000"
+                    ]
+                },
+                "timestamps": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "units": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HistoricalBatchResponse": {
+            "type": "object",
+            "properties": {
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.HistoricalBatchPosition"
+                    }
+                },
+                "upstream_calls": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.HistoricalRequest": {
+            "type": "object",
+            "required": [
+                "timestamp"
+            ],
+            "properties": {
+                "frame": {
+                    "type": "string",
+                    "enum": [
+                        "ecef",
+                        "eci",
+                        "teme",
+                        "j2000"
+                    ]
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ISSCrewResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "people": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Astronaut"
+                    }
+                }
+            }
+        },
+        "models.ISSCrewWithPhotosResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "people": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AstronautWithPhoto"
+                    }
+                }
+            }
+        },
+        "models.ISSPositionWithState": {
+            "type": "object",
+            "properties": {
+                "altitude": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daynum": {
+                    "type": "number"
+                },
+                "footprint": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_saa": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nearest_city": {
+                    "$ref": "#/definitions/models.NearbyPlace"
+                },
+                "satellite_id": {
+                    "type": "integer"
+                },
+                "solar_lat": {
+                    "type": "number"
+                },
+                "solar_lon": {
+                    "type": "number"
+                },
+                "state": {
+                    "$ref": "#/definitions/models.StateVector"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "velocity": {
+                    "type": "number"
+                },
+                "visibility": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Landmark": {
+            "type": "object",
+            "properties": {
+                "country_code": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.LandmarkOverflight": {
+            "type": "object",
+            "properties": {
+                "bearing_deg": {
+                    "type": "number"
+                },
+                "closest_approach_time": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "iss_latitude": {
+                    "type": "number"
+                },
+                "iss_longitude": {
+                    "type": "number"
+                },
+                "landmark": {
+                    "$ref": "#/definitions/models.Landmark"
+                },
+                "min_distance_km": {
+                    "type": "number"
+                },
+                "start_time": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LandmarkOverflightsResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "overflights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LandmarkOverflight"
+                    }
+                },
+                "radius_km": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.MissionEvent": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "delta_v_ms": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "end_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "modules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Module"
+                    }
+                },
+                "start_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "vehicles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VisitingVehicle"
+                    }
+                }
+            }
+        },
+        "models.Module": {
+            "type": "object",
+            "properties": {
+                "agency": {
+                    "type": "string"
+                },
+                "attachment_port": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/models.Dimensions"
+                },
+                "docking_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "launch_date": {
+                    "type": "string"
+                },
+                "mass_kg": {
+                    "type": "number"
+                },
+                "model_asset_url": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "transform": {
+                    "$ref": "#/definitions/models.Transform"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.NearbyPlace": {
+            "type": "object",
+            "properties": {
+                "bearing_deg": {
+                    "type": "number"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "country_code": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "population": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Observer": {
+            "type": "object",
+            "properties": {
+                "altitude_m": {
+                    "type": "number"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ObserverPass": {
+            "type": "object",
+            "properties": {
+                "aos": {
+                    "$ref": "#/definitions/models.SkyPoint"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "los": {
+                    "$ref": "#/definitions/models.SkyPoint"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SkyPoint"
+                    }
+                },
+                "sky_plot_url": {
+                    "type": "string"
+                },
+                "tca": {
+                    "$ref": "#/definitions/models.SkyPoint"
+                },
+                "visible": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ObserverPassesResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "hours": {
+                    "type": "integer"
+                },
+                "min_elevation": {
+                    "type": "number"
+                },
+                "observer": {
+                    "$ref": "#/definitions/models.Observer"
+                },
+                "passes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ObserverPass"
+                    }
+                }
+            }
+        },
+        "models.OrbitAltitude": {
+            "type": "object",
+            "properties": {
+                "end_timestamp": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mean_altitude_km": {
+                    "type": "number"
+                },
+                "samples": {
+                    "type": "integer"
+                },
+                "start_timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OverflightDayValue": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "seconds": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.OverflightRankingResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "string",
+                    "enum": [
+                        "day",
+                        "week",
+                        "all"
+                    ]
+                },
+                "regions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OverflightTotal"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total_seconds": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.OverflightSeriesResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OverflightDayValue"
+                    }
+                },
+                "region": {
+                    "$ref": "#/definitions/models.Region"
+                },
+                "total_seconds": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.OverflightTotal": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "number"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "region": {
+                    "$ref": "#/definitions/models.Region"
+                },
+                "seconds": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PortOccupancy": {
+            "type": "object",
+            "properties": {
+                "module_id": {
+                    "type": "integer"
+                },
+                "module_name": {
+                    "type": "string"
+                },
+                "port": {
+                    "type": "string"
+                },
+                "vehicle": {
+                    "$ref": "#/definitions/models.VisitingVehicle"
+                }
+            }
+        },
+        "models.PortOccupancyResponse": {
+            "type": "object",
+            "properties": {
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PortOccupancy"
+                    }
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Post": {
+            "type": "object",
+            "properties": {
                 "author": {
                     "type": "string"
                 },
                 "content": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
                 "excerpt": {
                     "type": "string"
                 },
-                "image": {
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publishDate": {
+                    "type": "string"
+                },
+                "readTime": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QuarantinedPosition": {
+            "type": "object",
+            "properties": {
+                "altitude": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "daynum": {
+                    "type": "number"
+                },
+                "footprint": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                },
+                "satellite_id": {
+                    "type": "integer"
+                },
+                "solar_lat": {
+                    "type": "number"
+                },
+                "solar_lon": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "units": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "velocity": {
+                    "type": "number"
                 },
-                "publishDate": {
+                "visibility": {
                     "type": "string"
+                }
+            }
+        },
+        "models.Quaternion": {
+            "type": "object",
+            "properties": {
+                "w": {
+                    "type": "number"
                 },
-                "readTime": {
-                    "type": "string"
+                "x": {
+                    "type": "number"
                 },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "y": {
+                    "type": "number"
                 },
-                "title": {
-                    "type": "string"
+                "z": {
+                    "type": "number"
                 }
             }
         },
-        "handlers.LoginRequest": {
+        "models.Region": {
             "type": "object",
             "properties": {
-                "password": {
+                "code": {
                     "type": "string"
                 },
-                "username": {
+                "name": {
                     "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "country",
+                        "ocean",
+                        "sea"
+                    ]
                 }
             }
         },
-        "handlers.LoginResponse": {
+        "models.SAAPass": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string"
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "entry_latitude": {
+                    "type": "number"
+                },
+                "entry_longitude": {
+                    "type": "number"
+                },
+                "entry_time": {
+                    "type": "integer"
+                },
+                "exit_latitude": {
+                    "type": "number"
+                },
+                "exit_longitude": {
+                    "type": "number"
+                },
+                "exit_time": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.RegisterRequest": {
+        "models.SAAStatusResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "in_saa": {
+                    "type": "boolean"
                 },
-                "password": {
-                    "type": "string"
+                "latitude": {
+                    "type": "number"
                 },
-                "username": {
-                    "type": "string"
+                "longitude": {
+                    "type": "number"
+                },
+                "timestamp": {
+                    "type": "integer"
+                },
+                "upcoming_passes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SAAPass"
+                    }
                 }
             }
         },
-        "models.Astronaut": {
+        "models.Satellite": {
             "type": "object",
             "properties": {
-                "craft": {
+                "active": {
+                    "type": "boolean"
+                },
+                "collection_interval": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
+                },
+                "norad_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.AstronautWithPhoto": {
+        "models.SkyPoint": {
             "type": "object",
             "properties": {
-                "imgUrl": {
-                    "type": "string"
+                "azimuth": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string"
+                "elevation": {
+                    "type": "number"
+                },
+                "range_km": {
+                    "type": "number"
+                },
+                "sunlit": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.SolarAngleResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "angle": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.StateVector": {
+            "type": "object",
+            "properties": {
+                "epoch": {
+                    "type": "integer"
+                },
+                "frame": {
                     "type": "string"
                 },
-                "message": {
+                "position": {
+                    "$ref": "#/definitions/models.Vector3"
+                },
+                "position_unit": {
+                    "type": "string"
+                },
+                "velocity": {
+                    "$ref": "#/definitions/models.Vector3"
+                },
+                "velocity_unit": {
                     "type": "string"
                 }
             }
         },
-        "models.HistoricalRequest": {
+        "models.TimeConversionResponse": {
             "type": "object",
-            "required": [
-                "timestamp"
-            ],
             "properties": {
-                "timestamp": {
+                "gast_deg": {
+                    "type": "number"
+                },
+                "gmst_deg": {
+                    "type": "number"
+                },
+                "gps_seconds": {
+                    "type": "number"
+                },
+                "gps_seconds_of_week": {
+                    "type": "number"
+                },
+                "gps_week": {
                     "type": "integer"
                 },
-                "units": {
+                "jd_tt": {
+                    "type": "number"
+                },
+                "jd_utc": {
+                    "type": "number"
+                },
+                "julian_centuries_tt": {
+                    "type": "number"
+                },
+                "mjd_utc": {
+                    "type": "number"
+                },
+                "tai": {
+                    "type": "string"
+                },
+                "tai_minus_utc": {
+                    "type": "number"
+                },
+                "tt": {
+                    "type": "string"
+                },
+                "unix": {
+                    "type": "number"
+                },
+                "utc": {
                     "type": "string"
                 }
             }
         },
-        "models.ISSCrewResponse": {
+        "models.Transform": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "position": {
+                    "$ref": "#/definitions/models.Vector3"
                 },
-                "people": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Astronaut"
-                    }
+                "rotation": {
+                    "$ref": "#/definitions/models.Quaternion"
+                },
+                "scale": {
+                    "type": "number"
                 }
             }
         },
-        "models.ISSCrewWithPhotosResponse": {
+        "models.Vector3": {
             "type": "object",
             "properties": {
-                "message": {
-                    "type": "string"
+                "x": {
+                    "type": "number"
                 },
-                "people": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.AstronautWithPhoto"
-                    }
+                "y": {
+                    "type": "number"
+                },
+                "z": {
+                    "type": "number"
                 }
             }
         },
-        "models.ISSPosition": {
+        "models.VisitingVehicle": {
             "type": "object",
             "properties": {
-                "altitude": {
-                    "type": "number"
+                "arrival_at": {
+                    "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "daynum": {
-                    "type": "number"
+                "departure_at": {
+                    "type": "string"
                 },
-                "footprint": {
-                    "type": "number"
+                "description": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "latitude": {
-                    "type": "number"
-                },
-                "longitude": {
-                    "type": "number"
-                },
-                "name": {
+                "model_asset_url": {
                     "type": "string"
                 },
-                "solar_lat": {
-                    "type": "number"
-                },
-                "solar_lon": {
-                    "type": "number"
+                "module": {
+                    "$ref": "#/definitions/models.Module"
                 },
-                "timestamp": {
+                "module_id": {
                     "type": "integer"
                 },
-                "units": {
+                "name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "port": {
                     "type": "string"
                 },
-                "velocity": {
-                    "type": "number"
+                "updated_at": {
+                    "type": "string"
                 },
-                "visibility": {
+                "vehicle_type": {
                     "type": "string"
                 }
             }
         },
-        "models.Post": {
+        "models.WebhookDelivery": {
             "type": "object",
             "properties": {
-                "author": {
-                    "type": "string"
+                "attempts": {
+                    "type": "integer"
                 },
-                "content": {
+                "created_at": {
                     "type": "string"
                 },
-                "created_at": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "excerpt": {
+                "event_type": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "image": {
+                "last_error": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "next_attempt_at": {
+                    "type": "string"
                 },
-                "publishDate": {
+                "payload": {
                     "type": "string"
                 },
-                "readTime": {
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
                     "type": "string"
                 },
-                "tags": {
+                "subscription_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookSubscription": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "title": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "models.SolarAngleResponse": {
+        "models.WebhookSubscriptionWithSecret": {
             "type": "object",
             "properties": {
-                "angle": {
-                    "type": "number"
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         }
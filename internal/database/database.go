@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"iss-model-backend/internal/models"
@@ -43,6 +44,10 @@ var (
 	dbInstance *service
 )
 
+// issNoradID is the satellite whose latest position the health check
+// reports.
+const issNoradID = 25544
+
 func New() Service {
 	if dbInstance != nil {
 		return dbInstance
@@ -75,10 +80,37 @@ func New() Service {
 	sqlDB.SetMaxOpenConns(100)
 	sqlDB.SetConnMaxLifetime(time.Hour)
 
-	if err := db.AutoMigrate(&models.ISSPosition{}, &models.Post{}, &models.User{}); err != nil {
+	if err := db.AutoMigrate(
+		&models.ISSPosition{},
+		&models.Post{},
+		&models.User{},
+		&models.Geofence{},
+		&models.GeofenceEvent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.Module{},
+		&models.VisitingVehicle{},
+		&models.MissionEvent{},
+		&models.OverflightDay{},
+		&models.CoverageCell{},
+		&models.CoverageProgress{},
+		&models.OrbitAltitude{},
+		&models.CalendarPass{},
+		&models.QuarantinedPosition{},
+		&models.Satellite{},
+	); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	if err := dropUniqueTimestampIndex(db); err != nil {
+		log.Printf("Warning: Failed to drop the unique timestamp index: %v", err)
+	}
+
+	// Superseded by idx_modules_name_active, which ignores deleted modules.
+	if err := db.Exec("DROP INDEX IF EXISTS idx_modules_name").Error; err != nil {
+		log.Printf("Warning: Failed to drop the unique module name index: %v", err)
+	}
+
 	if err := createIndexes(db); err != nil {
 		log.Printf("Warning: Failed to create indexes: %v", err)
 	}
@@ -91,6 +123,24 @@ func New() Service {
 	return dbInstance
 }
 
+// dropUniqueTimestampIndex drops the unique index on iss_positions.timestamp
+// from before positions were stored per satellite, which would reject two
+// satellites sampled at the same second. createIndexes recreates it as a
+// plain index.
+func dropUniqueTimestampIndex(db *gorm.DB) error {
+	var indexDef string
+	if err := db.Raw("SELECT indexdef FROM pg_indexes WHERE tablename = 'iss_positions' AND indexname = 'idx_iss_positions_timestamp'").
+		Scan(&indexDef).Error; err != nil {
+		return err
+	}
+	if !strings.Contains(indexDef, "UNIQUE") {
+		return nil
+	}
+
+	log.Println("Dropping the unique index on iss_positions.timestamp")
+	return db.Exec("DROP INDEX idx_iss_positions_timestamp").Error
+}
+
 func createIndexes(db *gorm.DB) error {
 	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_iss_positions_timestamp ON iss_positions (timestamp)").Error; err != nil {
 		return err
@@ -160,7 +210,7 @@ func (s *service) Health() map[string]string {
 	}
 
 	var latestPos models.ISSPosition
-	if err := s.db.Order("timestamp desc").First(&latestPos).Error; err == nil {
+	if err := s.db.Where("satellite_id = ?", issNoradID).Order("timestamp desc").First(&latestPos).Error; err == nil {
 		stats["latest_iss_timestamp"] = time.Unix(latestPos.Timestamp, 0).Format(time.RFC3339)
 
 		if time.Since(time.Unix(latestPos.Timestamp, 0)) > 2*time.Minute {
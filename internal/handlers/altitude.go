@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+type AltitudeHandler struct {
+	altitudeService *services.AltitudeService
+}
+
+func NewAltitudeHandler(altitudeService *services.AltitudeService) *AltitudeHandler {
+	return &AltitudeHandler{
+		altitudeService: altitudeService,
+	}
+}
+
+// @Summary Get Altitude Decay Trend
+// @Description Fits the orbital decay rate to the mean altitude of each complete revolution, collected from ascending node to ascending node and kept after the raw positions expire. The fit starts after the last reboost on the mission timeline; reboosts are detected automatically as step changes and logged there with their estimated delta-v.
+// @Tags Altitude
+// @Produce json
+// @Param days query int false "Days of history to analyze (default 14, max 365)"
+// @Success 200 {object} models.AltitudeTrendResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/altitude/trend [get]
+func (h *AltitudeHandler) HandleGetTrend(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseDays(w, r, "days", services.ALTITUDE_DEFAULT_TREND_DAYS, services.ALTITUDE_MAX_TREND_DAYS)
+	if !ok {
+		return
+	}
+
+	trend, err := h.altitudeService.GetTrend(days)
+	if err != nil {
+		if errors.Is(err, services.ErrInsufficientAltitudeData) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not enough altitude history", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to fit altitude trend", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, trend)
+}
+
+// @Summary Get Altitude Forecast
+// @Description Extrapolates the decay trend one point a day with a 95% prediction interval. The linear model knows nothing of solar activity or upcoming reboosts, so it is only meaningful for the next few weeks.
+// @Tags Altitude
+// @Produce json
+// @Param days query int false "Days to forecast (default 28, max 90)"
+// @Param trend_days query int false "Days of history to fit (default 14, max 365)"
+// @Success 200 {object} models.AltitudeForecastResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/altitude/forecast [get]
+func (h *AltitudeHandler) HandleGetForecast(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseDays(w, r, "days", services.ALTITUDE_DEFAULT_FORECAST_DAYS, services.ALTITUDE_MAX_FORECAST_DAYS)
+	if !ok {
+		return
+	}
+	trendDays, ok := parseDays(w, r, "trend_days", services.ALTITUDE_DEFAULT_TREND_DAYS, services.ALTITUDE_MAX_TREND_DAYS)
+	if !ok {
+		return
+	}
+
+	forecast, err := h.altitudeService.GetForecast(days, trendDays)
+	if err != nil {
+		if errors.Is(err, services.ErrInsufficientAltitudeData) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Not enough altitude history", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to forecast altitude", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, forecast)
+}
+
+// parseDays reads a day count query parameter between 1 and maxDays. On
+// invalid input it writes a 400 response and returns false.
+func parseDays(w http.ResponseWriter, r *http.Request, name string, defaultDays, maxDays int) (int, bool) {
+	daysStr := r.URL.Query().Get(name)
+	if daysStr == "" {
+		return defaultDays, true
+	}
+
+	days, err := strconv.Atoi(daysStr)
+	if err != nil || days <= 0 || days > maxDays {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid "+name, fmt.Sprintf("%s must be between 1 and %d", name, maxDays))
+		return 0, false
+	}
+	return days, true
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+type CalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+func NewCalendarHandler(calendarService *services.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// @Summary Get Calendar Feed
+// @Description iCalendar feed of the visible ISS passes over an observer plus visiting vehicle dockings and undockings and the curated mission events (spacewalks, reboosts, launches...), meant to be subscribed to from Google Calendar or any other calendar app. Every event keeps a stable UID so refreshes update entries instead of duplicating them. Pass times come from the same propagation as /iss/passes.
+// @Tags Calendar
+// @Produce text/calendar
+// @Param lat query number true "Observer latitude"
+// @Param lon query number true "Observer longitude"
+// @Param alt query number false "Observer altitude in meters (default 0)"
+// @Param hours query int false "Prediction horizon in hours (default 72, max 72)"
+// @Param min_elevation query number false "Only list passes reaching this elevation in degrees (default 10)"
+// @Success 200 {string} string "iCalendar document"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/calendar.ics [get]
+func (h *CalendarHandler) HandleGetFeed(w http.ResponseWriter, r *http.Request) {
+	observer, ok := parseObserver(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	hours := services.CALENDAR_DEFAULT_HOURS
+	if hoursStr := query.Get("hours"); hoursStr != "" {
+		parsed, err := strconv.Atoi(hoursStr)
+		if err != nil || parsed <= 0 || parsed > services.PASS_MAX_HOURS {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid hours", "hours must be between 1 and 72")
+			return
+		}
+		hours = parsed
+	}
+
+	minElevation := services.PASS_DEFAULT_MIN_ELEVATION
+	if elevationStr := query.Get("min_elevation"); elevationStr != "" {
+		parsed, err := strconv.ParseFloat(elevationStr, 64)
+		if err != nil || parsed < 0 || parsed >= 90 {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid min_elevation", "min_elevation must be between 0 and 90")
+			return
+		}
+		minElevation = parsed
+	}
+
+	feed, err := h.calendarService.GetFeed(observer, hours, minElevation, requestBaseURL(r))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to build calendar", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="iss.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(feed))
+}
+
+// requestBaseURL rebuilds the scheme and host the client used, honouring a
+// reverse proxy's X-Forwarded-Proto.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
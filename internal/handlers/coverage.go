@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CoverageHandler struct {
+	coverageService *services.CoverageService
+}
+
+func NewCoverageHandler(coverageService *services.CoverageService) *CoverageHandler {
+	return &CoverageHandler{
+		coverageService: coverageService,
+	}
+}
+
+// @Summary Get Coverage Grid
+// @Description Returns the ground-track density accumulated by the collector as a GeoJSON grid: one polygon per visited cell with the seconds spent there and a log-scaled density in [0, 1]
+// @Tags Coverage
+// @Produce json
+// @Param from query string false "First UTC day (YYYY-MM-DD, default: 29 days before to)"
+// @Param to query string false "Last UTC day (YYYY-MM-DD, default: today)"
+// @Param cell_deg query int false "Cell size in degrees, must divide 180" default(5)
+// @Success 200 {object} models.GeoJSONFeatureCollection
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/coverage [get]
+func (h *CoverageHandler) HandleGetGrid(w http.ResponseWriter, r *http.Request) {
+	from, to, err := services.ParseCoverageWindow(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time window", err.Error())
+		return
+	}
+
+	cellDeg := services.COVERAGE_DEFAULT_CELL_DEG
+	if cellStr := r.URL.Query().Get("cell_deg"); cellStr != "" {
+		if cellDeg, err = strconv.Atoi(cellStr); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid cell_deg", "cell_deg must be an integer")
+			return
+		}
+	}
+
+	grid, err := h.coverageService.GetCoverageGeoJSON(from, to, cellDeg)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCoverageQuery) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid query", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get coverage grid", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, grid)
+}
+
+// @Summary Get Coverage Tile
+// @Description Renders the ground-track density as a transparent 256x256 PNG map tile in the XYZ (Web Mercator) scheme, for use as an overlay in Leaflet, MapLibre or OpenLayers. Colours are scaled to the busiest cell of the whole time window, so tiles match at their edges.
+// @Tags Coverage
+// @Produce png
+// @Param z path int true "Zoom level (0-10)"
+// @Param x path int true "Tile column"
+// @Param y path int true "Tile row"
+// @Param from query string false "First UTC day (YYYY-MM-DD, default: 29 days before to)"
+// @Param to query string false "Last UTC day (YYYY-MM-DD, default: today)"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/coverage/{z}/{x}/{y}.png [get]
+func (h *CoverageHandler) HandleGetTile(w http.ResponseWriter, r *http.Request) {
+	z, errZ := strconv.Atoi(chi.URLParam(r, "z"))
+	x, errX := strconv.Atoi(chi.URLParam(r, "x"))
+	y, errY := strconv.Atoi(chi.URLParam(r, "y"))
+	if errZ != nil || errX != nil || errY != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid tile", "z, x and y must be integers")
+		return
+	}
+
+	from, to, err := services.ParseCoverageWindow(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time window", err.Error())
+		return
+	}
+
+	tile, err := h.coverageService.RenderCoverageTile(z, x, y, from, to)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCoverageQuery) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid tile", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to render coverage tile", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.WriteHeader(http.StatusOK)
+	w.Write(tile)
+}
@@ -29,6 +29,7 @@ func (h *CrewHandler) GetCurrentCrew(w http.ResponseWriter, r *http.Request) {
 	crew, err := h.crewService.GetCurrentCrew()
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get current crew", err.Error())
+		return
 	}
 
 	utils.SendJSONResponse(w, http.StatusOK, crew)
@@ -45,6 +46,7 @@ func (h *CrewHandler) GetCurrentCrewWithPhotos(w http.ResponseWriter, r *http.Re
 	crewWithPhotos, err := h.crewService.GetCurrentCrewWithPhotos()
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get current crew", err.Error())
+		return
 	}
 
 	utils.SendJSONResponse(w, http.StatusOK, crewWithPhotos)
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+type EarthHandler struct{}
+
+func NewEarthHandler() *EarthHandler {
+	return &EarthHandler{}
+}
+
+// GetTerminator returns the day/night terminator and twilight regions
+// @Summary Get Day/Night Terminator
+// @Description Returns a GeoJSON FeatureCollection with the solar subpoint, the civil (0° to -6° Sun elevation), nautical (-6° to -12°) and astronomical (-12° to -18°) twilight bands and the night region below -18°. Each band is a ring between two elevation limits, given as a polygon with a hole where needed, so bands can be styled separately. Polygons crossing the antimeridian are split into MultiPolygons.
+// @Tags Earth
+// @Produce json
+// @Param time query string false "Instant as Unix seconds or RFC 3339 (default: now)"
+// @Success 200 {object} models.GeoJSONFeatureCollection
+// @Failure 400 {object} models.ErrorResponse
+// @Router /earth/terminator [get]
+func (h *EarthHandler) GetTerminator(w http.ResponseWriter, r *http.Request) {
+	t, err := services.ParseTime(r.URL.Query().Get("time"), services.TIME_SCALE_UTC)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, services.GetTerminator(t))
+}
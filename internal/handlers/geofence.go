@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type GeofenceHandler struct {
+	geofenceService *services.GeofenceService
+}
+
+func NewGeofenceHandler(geofenceService *services.GeofenceService) *GeofenceHandler {
+	return &GeofenceHandler{
+		geofenceService: geofenceService,
+	}
+}
+
+type GeofenceRequest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Shape       string             `json:"shape" enums:"circle,polygon"`
+	CenterLat   float64            `json:"center_lat"`
+	CenterLon   float64            `json:"center_lon"`
+	RadiusKm    float64            `json:"radius_km"`
+	Polygon     models.Coordinates `json:"polygon"`
+	Active      *bool              `json:"active"`
+}
+
+func (req *GeofenceRequest) toGeofence() *models.Geofence {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &models.Geofence{
+		Name:        req.Name,
+		Description: req.Description,
+		Shape:       req.Shape,
+		CenterLat:   req.CenterLat,
+		CenterLon:   req.CenterLon,
+		RadiusKm:    req.RadiusKm,
+		Polygon:     req.Polygon,
+		Active:      active,
+	}
+}
+
+// @Summary Get All Geofences
+// @Description Returns all defined geofences
+// @Tags Geofences
+// @Produce json
+// @Success 200 {array} models.Geofence
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/geofences [get]
+func (h *GeofenceHandler) HandleGetAllGeofences(w http.ResponseWriter, r *http.Request) {
+	geofences, err := h.geofenceService.GetAllGeofences()
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get geofences", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, geofences)
+}
+
+// @Summary Get Geofence Events
+// @Description Returns enter and exit events recorded by the collector, newest first
+// @Tags Geofences
+// @Produce json
+// @Param geofence_id query int false "Only events of this geofence"
+// @Param from query int false "Start timestamp (Unix)"
+// @Param to query int false "End timestamp (Unix)"
+// @Param limit query int false "Maximum number of events (max 1000)" default(100)
+// @Success 200 {array} models.GeofenceEvent
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/geofences/events [get]
+func (h *GeofenceHandler) HandleGetGeofenceEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	var geofenceID uint64
+	var from, to int64
+	var limit int
+	var err error
+
+	if v := query.Get("geofence_id"); v != "" {
+		if geofenceID, err = strconv.ParseUint(v, 10, 32); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid geofence_id", err.Error())
+			return
+		}
+	}
+	if v := query.Get("from"); v != "" {
+		if from, err = strconv.ParseInt(v, 10, 64); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid from", "from must be a valid Unix timestamp")
+			return
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if to, err = strconv.ParseInt(v, 10, 64); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid to", "to must be a valid Unix timestamp")
+			return
+		}
+	}
+	if limit, err = parseLimit(r); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid limit", err.Error())
+		return
+	}
+
+	events, err := h.geofenceService.GetEvents(uint(geofenceID), from, to, limit)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get geofence events", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, events)
+}
+
+// @Summary Create Geofence
+// @Description Circles need center_lat, center_lon and radius_km; polygons need a ring of [lon, lat] points
+// @Security ApiKeyAuth
+// @Tags Geofences (Admin)
+// @Accept json
+// @Produce json
+// @Param request body GeofenceRequest true "Geofence data"
+// @Success 201 {object} models.Geofence
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/geofences [post]
+func (h *GeofenceHandler) HandleCreateGeofence(w http.ResponseWriter, r *http.Request) {
+	var req GeofenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	geofence, err := h.geofenceService.CreateGeofence(req.toGeofence())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidGeofence):
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid geofence", err.Error())
+		case errors.Is(err, services.ErrDuplicateGeofence):
+			utils.SendErrorResponse(w, http.StatusConflict, "Duplicate geofence", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to create geofence", err.Error())
+		}
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusCreated, geofence)
+}
+
+// @Summary Update Geofence
+// @Security ApiKeyAuth
+// @Tags Geofences (Admin)
+// @Accept json
+// @Produce json
+// @Param id path int true "Geofence ID"
+// @Param request body GeofenceRequest true "Geofence data"
+// @Success 200 {object} models.Geofence
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/geofences/{id} [put]
+func (h *GeofenceHandler) HandleUpdateGeofence(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req GeofenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	geofence, err := h.geofenceService.UpdateGeofence(uint(id), req.toGeofence())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidGeofence):
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid geofence", err.Error())
+		case errors.Is(err, services.ErrDuplicateGeofence):
+			utils.SendErrorResponse(w, http.StatusConflict, "Duplicate geofence", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to update geofence", err.Error())
+		}
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, geofence)
+}
+
+// @Summary Delete Geofence
+// @Security ApiKeyAuth
+// @Tags Geofences (Admin)
+// @Param id path int true "Geofence ID"
+// @Success 204 "No Content"
+// @Router /admin/geofences/{id} [delete]
+func (h *GeofenceHandler) HandleDeleteGeofence(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.geofenceService.DeleteGeofence(uint(id)); err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to delete geofence", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
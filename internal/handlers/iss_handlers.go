@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -14,15 +16,43 @@ import (
 )
 
 type ISSHandler struct {
-	issService *services.ISSService
+	issService       *services.ISSService
+	satelliteService *services.SatelliteService
 }
 
-func NewISSHandler(issService *services.ISSService) *ISSHandler {
+func NewISSHandler(issService *services.ISSService, satelliteService *services.SatelliteService) *ISSHandler {
 	return &ISSHandler{
-		issService: issService,
+		issService:       issService,
+		satelliteService: satelliteService,
 	}
 }
 
+// satelliteFor returns the service of the satellite in the norad path
+// parameter, or the ISS service on /iss routes. On an invalid or unknown
+// satellite it writes an error response and returns false.
+func (h *ISSHandler) satelliteFor(w http.ResponseWriter, r *http.Request) (*services.ISSService, bool) {
+	if chi.URLParam(r, "norad") == "" {
+		return h.issService, true
+	}
+
+	noradID, ok := parseNoradID(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	service, err := h.satelliteService.ServiceFor(noradID)
+	if err != nil {
+		if errors.Is(err, services.ErrSatelliteNotFound) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Satellite not found", err.Error())
+			return nil, false
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get satellite", err.Error())
+		return nil, false
+	}
+
+	return service, true
+}
+
 // GetCurrentPosition returns the current ISS position
 // @Summary Get Current ISS Position
 // @Description Returns the current position of the International Space Station
@@ -30,7 +60,11 @@ func NewISSHandler(issService *services.ISSService) *ISSHandler {
 // @Accept json
 // @Produce json
 // @Param units query string false "Units (kilometers or miles)" Enums(kilometers, miles) default(kilometers)
-// @Success 200 {object} models.ISSPosition
+// @Param frame query string false "Add a Cartesian state vector in this frame (eci is an alias of j2000)" Enums(ecef, eci, teme, j2000)
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {object} models.ISSPositionWithState
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /iss/current [get]
 func (h *ISSHandler) GetCurrentPosition(w http.ResponseWriter, r *http.Request) {
@@ -39,13 +73,23 @@ func (h *ISSHandler) GetCurrentPosition(w http.ResponseWriter, r *http.Request)
 		units = "kilometers"
 	}
 
-	position, err := h.issService.GetCurrentPosition(units)
+	frame, ok := parseFrame(w, r.URL.Query().Get("frame"))
+	if !ok {
+		return
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	position, err := issService.GetCurrentPosition(units)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get current position", err.Error())
 		return
 	}
 
-	utils.SendJSONResponse(w, http.StatusOK, position)
+	h.sendWithState(w, issService, position, frame)
 }
 
 // GetHistoricalPosition returns ISS position for a specific timestamp
@@ -56,7 +100,8 @@ func (h *ISSHandler) GetCurrentPosition(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Param timestamp path int true "Unix timestamp"
 // @Param units query string false "Units (kilometers or miles)" Enums(kilometers, miles) default(kilometers)
-// @Success 200 {object} models.ISSPosition
+// @Param frame query string false "Add a Cartesian state vector in this frame (eci is an alias of j2000)" Enums(ecef, eci, teme, j2000)
+// @Success 200 {object} models.ISSPositionWithState
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
@@ -74,7 +119,17 @@ func (h *ISSHandler) GetHistoricalPosition(w http.ResponseWriter, r *http.Reques
 		units = "kilometers"
 	}
 
-	position, err := h.issService.GetHistoricalPosition(timestamp, units)
+	frame, ok := parseFrame(w, r.URL.Query().Get("frame"))
+	if !ok {
+		return
+	}
+
+	issService, ok := h.satelliteFor(w, r)
+	if !ok {
+		return
+	}
+
+	position, err := issService.GetHistoricalPosition(timestamp, units)
 	if err != nil {
 		if err.Error() == "Timestamp outside set range (4 hours back/forward)" {
 			utils.SendErrorResponse(w, http.StatusBadRequest, "Timestamp out of range", err.Error())
@@ -89,7 +144,7 @@ func (h *ISSHandler) GetHistoricalPosition(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	utils.SendJSONResponse(w, http.StatusOK, position)
+	h.sendWithState(w, issService, position, frame)
 }
 
 // GetPositionsInRange returns ISS positions within a time range
@@ -101,7 +156,8 @@ func (h *ISSHandler) GetHistoricalPosition(w http.ResponseWriter, r *http.Reques
 // @Param start_time query int true "Start timestamp (Unix)"
 // @Param end_time query int true "End timestamp (Unix)"
 // @Param units query string false "Units (kilometers or miles)" Enums(kilometers, miles) default(kilometers)
-// @Success 200 {array} models.ISSPosition
+// @Param frame query string false "Add a Cartesian state vector in this frame (eci is an alias of j2000)" Enums(ecef, eci, teme, j2000)
+// @Success 200 {array} models.ISSPositionWithState
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /iss/range [get]
@@ -141,13 +197,29 @@ func (h *ISSHandler) GetPositionsInRange(w http.ResponseWriter, r *http.Request)
 		units = "kilometers"
 	}
 
-	positions, err := h.issService.GetPositionsInRange(startTime, endTime, units)
+	frame, ok := parseFrame(w, r.URL.Query().Get("frame"))
+	if !ok {
+		return
+	}
+
+	issService, ok := h.satelliteFor(w, r)
+	if !ok {
+		return
+	}
+
+	positions, err := issService.GetPositionsInRange(startTime, endTime, units)
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get positions", err.Error())
 		return
 	}
 
-	utils.SendJSONResponse(w, http.StatusOK, positions)
+	withStates, err := issService.WithStates(positions, frame)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to compute state vectors", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, withStates)
 }
 
 // GetISSStatus returns general ISS tracking status and statistics
@@ -156,17 +228,25 @@ func (h *ISSHandler) GetPositionsInRange(w http.ResponseWriter, r *http.Request)
 // @Tags ISS
 // @Accept json
 // @Produce json
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
 // @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /iss/status [get]
 func (h *ISSHandler) GetISSStatus(w http.ResponseWriter, r *http.Request) {
-	currentPos, err := h.issService.GetCurrentPosition("kilometers")
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	currentPos, err := issService.GetCurrentPosition("kilometers")
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get current position", err.Error())
 		return
 	}
 
-	stats, err := h.issService.GetStatistics()
+	stats, err := issService.GetStatistics()
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get statistics", err.Error())
 		return
@@ -175,12 +255,22 @@ func (h *ISSHandler) GetISSStatus(w http.ResponseWriter, r *http.Request) {
 	status := map[string]any{
 		"status":              "operational",
 		"current_position":    currentPos,
+		"in_saa":              services.InSAA(currentPos.Latitude, currentPos.Longitude),
 		"data_retention":      "4 hours back and forward",
 		"collection_interval": "10 seconds",
 		"last_update":         time.Unix(currentPos.Timestamp, 0).Format(time.RFC3339),
 		"api_source":          "wheretheiss.at",
 		"supported_units":     []string{"kilometers", "miles"},
 		"statistics":          stats,
+		"satellite_id":        issService.SatelliteID(),
+	}
+
+	if satellite, err := h.satelliteService.GetSatellite(issService.SatelliteID()); err == nil {
+		status["collection_interval"] = fmt.Sprintf("%d seconds", satellite.CollectionInterval)
+	}
+
+	if issService.Simulated() {
+		status["simulated_time"] = issService.Now().UTC().Format(time.RFC3339)
 	}
 
 	utils.SendJSONResponse(w, http.StatusOK, status)
@@ -193,7 +283,7 @@ func (h *ISSHandler) GetISSStatus(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param request body models.HistoricalRequest true "Historical position request"
-// @Success 200 {object} models.ISSPosition
+// @Success 200 {object} models.ISSPositionWithState
 // @Failure 400 {object} models.ErrorResponse
 // @Failure 404 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
@@ -216,7 +306,17 @@ func (h *ISSHandler) PostHistoricalRequest(w http.ResponseWriter, r *http.Reques
 		units = "kilometers"
 	}
 
-	position, err := h.issService.GetHistoricalPosition(req.Timestamp, units)
+	frame, ok := parseFrame(w, req.Frame)
+	if !ok {
+		return
+	}
+
+	issService, ok := h.satelliteFor(w, r)
+	if !ok {
+		return
+	}
+
+	position, err := issService.GetHistoricalPosition(req.Timestamp, units)
 	if err != nil {
 		if err.Error() == "timestamp outside retention window (4 hours back/forward)" {
 			utils.SendErrorResponse(w, http.StatusBadRequest, "Timestamp out of range", err.Error())
@@ -231,7 +331,81 @@ func (h *ISSHandler) PostHistoricalRequest(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	utils.SendJSONResponse(w, http.StatusOK, position)
+	h.sendWithState(w, issService, position, frame)
+}
+
+// PostHistoricalBatch handles POST requests for the positions at many timestamps
+// @Summary Get Historical ISS Positions in Batch
+// @Description Returns the ISS positions for up to 500 timestamps in request order, e.g. the keyframes of a whole orbit. Each comes from a stored sample, is interpolated between stored samples, or is fetched from wheretheiss.at in paced batches of 10; source says which
+// @Tags ISS
+// @Accept json
+// @Produce json
+// @Param request body models.HistoricalBatchRequest true "Historical batch request"
+// @Success 200 {object} models.HistoricalBatchResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /iss/historical/batch [post]
+func (h *ISSHandler) PostHistoricalBatch(w http.ResponseWriter, r *http.Request) {
+	var req models.HistoricalBatchRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	units := req.Units
+	if units != "miles" {
+		units = "kilometers"
+	}
+
+	frame, ok := parseFrame(w, req.Frame)
+	if !ok {
+		return
+	}
+
+	issService, ok := h.satelliteFor(w, r)
+	if !ok {
+		return
+	}
+
+	response, err := issService.GetHistoricalBatch(req.Timestamps, units, frame)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidHistoricalBatch):
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid batch", err.Error())
+		case errors.Is(err, services.ErrUpstreamUnavailable), errors.Is(err, services.ErrUpstreamThrottled):
+			utils.SendErrorResponse(w, http.StatusServiceUnavailable, "Upstream API unavailable", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get historical positions", err.Error())
+		}
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, response)
+}
+
+// sendWithState writes position, with its state vector when frame is set.
+func (h *ISSHandler) sendWithState(w http.ResponseWriter, issService *services.ISSService, position *models.ISSPosition, frame string) {
+	result, err := issService.WithState(position, frame)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to compute state vector", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, result)
+}
+
+// parseFrame validates a frame parameter. On invalid input it writes a 400
+// response and returns false.
+func parseFrame(w http.ResponseWriter, value string) (string, bool) {
+	frame, err := services.ParseFrame(value)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid frame", err.Error())
+		return "", false
+	}
+
+	return frame, true
 }
 
 // GetSolarAngle zwraca obliczony kąt azymutu słońca
@@ -240,11 +414,19 @@ func (h *ISSHandler) PostHistoricalRequest(w http.ResponseWriter, r *http.Reques
 // @Tags ISS
 // @Accept json
 // @Produce json
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
 // @Success 200 {object} models.SolarAngleResponse
+// @Failure 400 {object} models.ErrorResponse
 // @Failure 500 {object} models.ErrorResponse
 // @Router /iss/solar-angle [get]
 func (h *ISSHandler) GetSolarAngle(w http.ResponseWriter, r *http.Request) {
-	angle, err := h.issService.GetSolarAngle()
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	angle, err := issService.GetSolarAngle()
 	if err != nil {
 		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to calculate solar angle", err.Error())
 		return
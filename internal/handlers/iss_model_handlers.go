@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/utils"
+)
+
+// GetAttitude returns the nominal ISS attitude for the 3D model
+// @Summary Get ISS Attitude
+// @Description Returns the nominal LVLH (+XVV) attitude as a quaternion rotating body-frame vectors into ECEF, plus the nadir, velocity and sun unit vectors in the body frame (X along velocity, Y opposite the orbit normal, Z towards nadir)
+// @Tags ISS Model
+// @Produce json
+// @Param timestamp query int false "Unix timestamp (default: now)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {object} models.AttitudeResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/model/attitude [get]
+func (h *ISSHandler) GetAttitude(w http.ResponseWriter, r *http.Request) {
+	timestamp, ok := parseOptionalTimestamp(w, r)
+	if !ok {
+		return
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	attitude, err := issService.GetAttitude(timestamp)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get attitude", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, attitude)
+}
+
+// GetGimbalAngles returns nominal solar array and radiator joint angles
+// @Summary Get ISS Gimbal Angles
+// @Description Returns the nominal sun-tracking angles of the solar alpha rotary joints (SARJ), beta gimbal assemblies (BGA) and radiator rotary joints (TRRJ) in degrees. SARJ alpha is a rotation about body +Y with 0° facing zenith; BGA beta tilts the arrays towards +Y.
+// @Tags ISS Model
+// @Produce json
+// @Param timestamp query int false "Unix timestamp (default: now)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {object} models.GimbalAnglesResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/model/gimbals [get]
+func (h *ISSHandler) GetGimbalAngles(w http.ResponseWriter, r *http.Request) {
+	timestamp, ok := parseOptionalTimestamp(w, r)
+	if !ok {
+		return
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	angles, err := issService.GetGimbalAngles(timestamp)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get gimbal angles", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, angles)
+}
+
+// parseOptionalTimestamp reads the "timestamp" query parameter. A missing
+// parameter yields 0, which services treat as "now". On invalid input it
+// writes a 400 response and returns false.
+func parseOptionalTimestamp(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	timestampStr := r.URL.Query().Get("timestamp")
+	if timestampStr == "" {
+		return 0, true
+	}
+
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil || timestamp <= 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid timestamp", "Timestamp must be a valid Unix timestamp")
+		return 0, false
+	}
+
+	return timestamp, true
+}
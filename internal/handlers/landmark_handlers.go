@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+// GetLandmarks lists the landmarks overflights can be predicted for
+// @Summary List Landmarks
+// @Description Returns the embedded landmarks (monuments, mountains, spaceports, natural features) with their ids for use with /iss/landmarks/upcoming
+// @Tags ISS
+// @Produce json
+// @Success 200 {array} models.Landmark
+// @Router /iss/landmarks [get]
+func (h *ISSHandler) GetLandmarks(w http.ResponseWriter, r *http.Request) {
+	utils.SendJSONResponse(w, http.StatusOK, services.Landmarks())
+}
+
+// GetUpcomingLandmarks predicts overflights of landmarks
+// @Summary Get Upcoming Landmark Overflights
+// @Description Predicts passes of the ISS sub-satellite point within radius_km of the selected landmarks over the next hours by propagating the current orbit (two-body + J2, no drag). Each pass reports its closest approach and the bearing from the ISS to the landmark at that moment.
+// @Tags ISS
+// @Produce json
+// @Param hours query int false "Prediction horizon in hours (default 12, max 24)"
+// @Param landmarks query string false "Comma-separated landmark ids (default: all)"
+// @Param radius_km query number false "Search radius around each landmark in km (default 150, max 1000)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {object} models.LandmarkOverflightsResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/landmarks/upcoming [get]
+func (h *ISSHandler) GetUpcomingLandmarks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	hours := services.LANDMARK_DEFAULT_HOURS
+	if hoursStr := query.Get("hours"); hoursStr != "" {
+		parsed, err := strconv.Atoi(hoursStr)
+		if err != nil || parsed <= 0 || parsed > services.LANDMARK_MAX_HOURS {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid hours", "hours must be between 1 and 24")
+			return
+		}
+		hours = parsed
+	}
+
+	radiusKm := services.LANDMARK_DEFAULT_RADIUS_KM
+	if radiusStr := query.Get("radius_km"); radiusStr != "" {
+		parsed, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || parsed <= 0 || parsed > services.LANDMARK_MAX_RADIUS_KM {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid radius_km", "radius_km must be between 0 and 1000")
+			return
+		}
+		radiusKm = parsed
+	}
+
+	var ids []string
+	for _, id := range strings.Split(query.Get("landmarks"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := issService.GetUpcomingLandmarkOverflights(hours, ids, radiusKm)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownLandmark) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid landmarks", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to predict landmark overflights", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MissionEventHandler struct {
+	missionEventService *services.MissionEventService
+}
+
+func NewMissionEventHandler(missionEventService *services.MissionEventService) *MissionEventHandler {
+	return &MissionEventHandler{
+		missionEventService: missionEventService,
+	}
+}
+
+type MissionEventRequest struct {
+	Type        string     `json:"type" enums:"eva,docking,undocking,reboost,launch,experiment"`
+	Title       string     `json:"title" example:"US EVA 91"`
+	Description string     `json:"description"`
+	StartAt     time.Time  `json:"start_at"`
+	EndAt       *time.Time `json:"end_at"`
+	Crew        []string   `json:"crew"`
+	DeltaVMs    *float64   `json:"delta_v_ms"`
+	ModuleIDs   []uint     `json:"module_ids"`
+	VehicleIDs  []uint     `json:"vehicle_ids"`
+}
+
+func (req *MissionEventRequest) toEvent() *models.MissionEvent {
+	return &models.MissionEvent{
+		Type:        req.Type,
+		Title:       req.Title,
+		Description: req.Description,
+		StartAt:     req.StartAt,
+		EndAt:       req.EndAt,
+		Crew:        req.Crew,
+		DeltaVMs:    req.DeltaVMs,
+	}
+}
+
+// @Summary Get Mission Events
+// @Description Returns the curated mission timeline (spacewalks, dockings, reboosts, launches, experiments) in chronological order
+// @Tags Events
+// @Produce json
+// @Param type query string false "Only events of this type" Enums(eva, docking, undocking, reboost, launch, experiment)
+// @Param from query int false "Only events ending at or after this Unix timestamp"
+// @Param to query int false "Only events starting at or before this Unix timestamp"
+// @Param timestamp query int false "Only events in progress at this Unix timestamp"
+// @Param crew query string false "Only events involving this crew member"
+// @Param module_id query int false "Only events related to this module"
+// @Param vehicle_id query int false "Only events related to this visiting vehicle"
+// @Param limit query int false "Maximum number of events (default 100, max 1000)"
+// @Success 200 {array} models.MissionEvent
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/events [get]
+func (h *MissionEventHandler) HandleGetAllEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := services.MissionEventFilter{
+		Type: query.Get("type"),
+		Crew: query.Get("crew"),
+	}
+
+	var moduleID, vehicleID uint64
+	var err error
+
+	if v := query.Get("from"); v != "" {
+		if filter.From, err = strconv.ParseInt(v, 10, 64); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid from", "from must be a valid Unix timestamp")
+			return
+		}
+	}
+	if v := query.Get("to"); v != "" {
+		if filter.To, err = strconv.ParseInt(v, 10, 64); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid to", "to must be a valid Unix timestamp")
+			return
+		}
+	}
+	if v := query.Get("module_id"); v != "" {
+		if moduleID, err = strconv.ParseUint(v, 10, 32); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid module_id", err.Error())
+			return
+		}
+	}
+	if v := query.Get("vehicle_id"); v != "" {
+		if vehicleID, err = strconv.ParseUint(v, 10, 32); err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid vehicle_id", err.Error())
+			return
+		}
+	}
+	if filter.Limit, err = parseLimit(r); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid limit", err.Error())
+		return
+	}
+	filter.ModuleID = uint(moduleID)
+	filter.VehicleID = uint(vehicleID)
+
+	timestamp, ok := parseOptionalTimestamp(w, r)
+	if !ok {
+		return
+	}
+	if timestamp != 0 {
+		filter.From, filter.To = timestamp, timestamp
+	}
+
+	events, err := h.missionEventService.GetEvents(filter)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get events", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, events)
+}
+
+// @Summary Get Mission Event by ID
+// @Tags Events
+// @Produce json
+// @Param id path int true "Event ID"
+// @Success 200 {object} models.MissionEvent
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /iss/events/{id} [get]
+func (h *MissionEventHandler) HandleGetEventByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	event, err := h.missionEventService.GetEventByID(uint(id))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusNotFound, "Event not found", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, event)
+}
+
+// @Summary Create Mission Event
+// @Security ApiKeyAuth
+// @Tags Events (Admin)
+// @Accept json
+// @Produce json
+// @Param request body MissionEventRequest true "Event data"
+// @Success 201 {object} models.MissionEvent
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/events [post]
+func (h *MissionEventHandler) HandleCreateEvent(w http.ResponseWriter, r *http.Request) {
+	var req MissionEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	event, err := h.missionEventService.CreateEvent(req.toEvent(), req.ModuleIDs, req.VehicleIDs)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMissionEvent) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid event", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to create event", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusCreated, event)
+}
+
+// @Summary Update Mission Event
+// @Security ApiKeyAuth
+// @Tags Events (Admin)
+// @Accept json
+// @Produce json
+// @Param id path int true "Event ID"
+// @Param request body MissionEventRequest true "Event data"
+// @Success 200 {object} models.MissionEvent
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/events/{id} [put]
+func (h *MissionEventHandler) HandleUpdateEvent(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req MissionEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	event, err := h.missionEventService.UpdateEvent(uint(id), req.toEvent(), req.ModuleIDs, req.VehicleIDs)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidMissionEvent) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid event", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to update event", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, event)
+}
+
+// @Summary Delete Mission Event
+// @Security ApiKeyAuth
+// @Tags Events (Admin)
+// @Param id path int true "Event ID"
+// @Success 204 "No Content"
+// @Router /admin/iss/events/{id} [delete]
+func (h *MissionEventHandler) HandleDeleteEvent(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.missionEventService.DeleteEvent(uint(id)); err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to delete event", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ModuleHandler struct {
+	moduleService *services.ModuleService
+}
+
+func NewModuleHandler(moduleService *services.ModuleService) *ModuleHandler {
+	return &ModuleHandler{
+		moduleService: moduleService,
+	}
+}
+
+type ModuleRequest struct {
+	Name           string            `json:"name"`
+	Description    string            `json:"description"`
+	LaunchDate     string            `json:"launch_date" example:"1998-11-20"`
+	MassKg         float64           `json:"mass_kg"`
+	Dimensions     models.Dimensions `json:"dimensions"`
+	Agency         string            `json:"agency"`
+	AttachmentPort string            `json:"attachment_port"`
+	DockingPorts   []string          `json:"docking_ports"`
+	Transform      models.Transform  `json:"transform"`
+	ModelAssetURL  string            `json:"model_asset_url"`
+}
+
+func (req *ModuleRequest) toModule() (*models.Module, error) {
+	launchDate, err := parseOptionalDate(req.LaunchDate)
+	if err != nil {
+		return nil, fmt.Errorf("launch_date: %w", err)
+	}
+
+	return &models.Module{
+		Name:           req.Name,
+		Description:    req.Description,
+		LaunchDate:     launchDate,
+		MassKg:         req.MassKg,
+		Dimensions:     req.Dimensions,
+		Agency:         req.Agency,
+		AttachmentPort: req.AttachmentPort,
+		DockingPorts:   req.DockingPorts,
+		Transform:      req.Transform,
+		ModelAssetURL:  req.ModelAssetURL,
+	}, nil
+}
+
+// @Summary Get All Modules
+// @Description Returns the ISS module catalog ordered by launch date
+// @Tags Modules
+// @Produce json
+// @Success 200 {array} models.Module
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/modules [get]
+func (h *ModuleHandler) HandleGetAllModules(w http.ResponseWriter, r *http.Request) {
+	modules, err := h.moduleService.GetAllModules()
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get modules", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, modules)
+}
+
+// @Summary Get Module by ID
+// @Tags Modules
+// @Produce json
+// @Param id path int true "Module ID"
+// @Success 200 {object} models.Module
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /iss/modules/{id} [get]
+func (h *ModuleHandler) HandleGetModuleByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	module, err := h.moduleService.GetModuleByID(uint(id))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusNotFound, "Module not found", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, module)
+}
+
+// @Summary Create Module
+// @Security ApiKeyAuth
+// @Tags Modules (Admin)
+// @Accept json
+// @Produce json
+// @Param request body ModuleRequest true "Module data"
+// @Success 201 {object} models.Module
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/modules [post]
+func (h *ModuleHandler) HandleCreateModule(w http.ResponseWriter, r *http.Request) {
+	var req ModuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	module, err := req.toModule()
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid module", err.Error())
+		return
+	}
+
+	module, err = h.moduleService.CreateModule(module)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidModule):
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid module", err.Error())
+		case errors.Is(err, services.ErrDuplicateModule):
+			utils.SendErrorResponse(w, http.StatusConflict, "Duplicate module", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to create module", err.Error())
+		}
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusCreated, module)
+}
+
+// @Summary Update Module
+// @Security ApiKeyAuth
+// @Tags Modules (Admin)
+// @Accept json
+// @Produce json
+// @Param id path int true "Module ID"
+// @Param request body ModuleRequest true "Module data"
+// @Success 200 {object} models.Module
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/modules/{id} [put]
+func (h *ModuleHandler) HandleUpdateModule(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req ModuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	module, err := req.toModule()
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid module", err.Error())
+		return
+	}
+
+	module, err = h.moduleService.UpdateModule(uint(id), module)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidModule):
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid module", err.Error())
+		case errors.Is(err, services.ErrDuplicateModule):
+			utils.SendErrorResponse(w, http.StatusConflict, "Duplicate module", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to update module", err.Error())
+		}
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, module)
+}
+
+// @Summary Delete Module
+// @Security ApiKeyAuth
+// @Tags Modules (Admin)
+// @Param id path int true "Module ID"
+// @Success 204 "No Content"
+// @Router /admin/iss/modules/{id} [delete]
+func (h *ModuleHandler) HandleDeleteModule(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.moduleService.DeleteModule(uint(id)); err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to delete module", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseOptionalDate accepts either a plain date (2006-01-02) or an RFC 3339
+// timestamp. An empty string yields nil.
+func parseOptionalDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("expected YYYY-MM-DD or RFC 3339, got %q", value)
+	}
+	return &t, nil
+}
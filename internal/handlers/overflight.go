@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type OverflightHandler struct {
+	overflightService *services.OverflightService
+}
+
+func NewOverflightHandler(overflightService *services.OverflightService) *OverflightHandler {
+	return &OverflightHandler{
+		overflightService: overflightService,
+	}
+}
+
+// @Summary Get Overflight Ranking
+// @Description Ranks countries, oceans and seas by the time the ISS spent over them, accumulated by the collector. Regions come from offline reverse geocoding against embedded land and sea outlines, with the nearest embedded city or capital picking the country, so time near borders and coasts is approximate.
+// @Tags Overflights
+// @Produce json
+// @Param period query string false "today (UTC), the last 7 days or all time" Enums(day, week, all) default(day)
+// @Param type query string false "Only list this kind of region" Enums(country, ocean, sea)
+// @Param limit query int false "Maximum number of regions (default: all)"
+// @Success 200 {object} models.OverflightRankingResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/overflights [get]
+func (h *OverflightHandler) HandleGetRanking(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = models.OverflightPeriodDay
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil || limit < 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid limit", "limit must be a non-negative integer")
+		return
+	}
+
+	ranking, err := h.overflightService.GetRanking(period, r.URL.Query().Get("type"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidOverflightQuery) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid query", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get overflight ranking", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, ranking)
+}
+
+// @Summary Get Region Overflight Series
+// @Description Returns the daily time the ISS spent over one region (ISO country code such as PL, or an ocean/sea code such as atlantic_ocean), with days without overflights as zero
+// @Tags Overflights
+// @Produce json
+// @Param code path string true "Region code"
+// @Param days query int false "Number of UTC days ending today (max 366)" default(30)
+// @Success 200 {object} models.OverflightSeriesResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/overflights/{code} [get]
+func (h *OverflightHandler) HandleGetSeries(w http.ResponseWriter, r *http.Request) {
+	days := services.OVERFLIGHT_DEFAULT_DAYS
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid days", "days must be an integer")
+			return
+		}
+		days = parsed
+	}
+
+	series, err := h.overflightService.GetSeries(chi.URLParam(r, "code"), days)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidOverflightQuery):
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid query", err.Error())
+		case errors.Is(err, services.ErrUnknownRegion):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Region not found", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get overflight series", err.Error())
+		}
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, series)
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetPasses predicts ISS passes over an observer
+// @Summary Get Upcoming Passes
+// @Description Predicts passes of the ISS above an observer's horizon over the next hours by propagating the current orbit (two-body + J2, no drag). Each pass has its AOS, TCA and LOS, the sampled path across the sky with sunlit/eclipsed flags, whether it is visible to the naked eye (ISS sunlit, observer's sky dark) and the URL of its SVG sky plot.
+// @Tags ISS
+// @Produce json
+// @Param lat query number true "Observer latitude"
+// @Param lon query number true "Observer longitude"
+// @Param alt query number false "Observer altitude in meters (default 0)"
+// @Param hours query int false "Prediction horizon in hours (default 24, max 72)"
+// @Param min_elevation query number false "Only list passes reaching this elevation in degrees (default 10)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {object} models.ObserverPassesResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/passes [get]
+func (h *ISSHandler) GetPasses(w http.ResponseWriter, r *http.Request) {
+	observer, ok := parseObserver(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	hours := services.PASS_DEFAULT_HOURS
+	if hoursStr := query.Get("hours"); hoursStr != "" {
+		parsed, err := strconv.Atoi(hoursStr)
+		if err != nil || parsed <= 0 || parsed > services.PASS_MAX_HOURS {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid hours", "hours must be between 1 and 72")
+			return
+		}
+		hours = parsed
+	}
+
+	minElevation := services.PASS_DEFAULT_MIN_ELEVATION
+	if elevationStr := query.Get("min_elevation"); elevationStr != "" {
+		parsed, err := strconv.ParseFloat(elevationStr, 64)
+		if err != nil || parsed < 0 || parsed >= 90 {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid min_elevation", "min_elevation must be between 0 and 90")
+			return
+		}
+		minElevation = parsed
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := issService.GetUpcomingPasses(observer, hours, minElevation)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to predict passes", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, result)
+}
+
+// GetPassSkyPlot draws one pass as an SVG polar plot
+// @Summary Get Pass Sky Plot
+// @Description Draws a pass listed by /iss/passes as a printable SVG polar plot of azimuth and elevation, with AOS, TCA and LOS marked and the sunlit and eclipsed parts of the path told apart. Times are UTC.
+// @Tags ISS
+// @Produce image/svg+xml
+// @Param aos path int true "AOS of the pass (Unix timestamp, as listed by /iss/passes)"
+// @Param lat query number true "Observer latitude"
+// @Param lon query number true "Observer longitude"
+// @Param alt query number false "Observer altitude in meters (default 0)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {string} string "SVG document"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/passes/{aos}/sky.svg [get]
+func (h *ISSHandler) GetPassSkyPlot(w http.ResponseWriter, r *http.Request) {
+	aos, err := strconv.ParseInt(chi.URLParam(r, "aos"), 10, 64)
+	if err != nil || aos <= 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid aos", "aos must be a valid Unix timestamp")
+		return
+	}
+
+	observer, ok := parseObserver(w, r)
+	if !ok {
+		return
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	svg, err := issService.GetPassSkyPlot(observer, aos)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownPass) {
+			utils.SendErrorResponse(w, http.StatusNotFound, "Pass not found", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to draw sky plot", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(svg))
+}
+
+// parseObserver reads the observer from the lat, lon and alt query
+// parameters. On invalid input it writes a 400 response and returns false.
+func parseObserver(w http.ResponseWriter, r *http.Request) (models.Observer, bool) {
+	query := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid lat", "lat must be between -90 and 90")
+		return models.Observer{}, false
+	}
+
+	lon, err := strconv.ParseFloat(query.Get("lon"), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid lon", "lon must be between -180 and 180")
+		return models.Observer{}, false
+	}
+
+	alt := 0.0
+	if altStr := query.Get("alt"); altStr != "" {
+		alt, err = strconv.ParseFloat(altStr, 64)
+		if err != nil || alt < -500 || alt > 9000 {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid alt", "alt must be between -500 and 9000 meters")
+			return models.Observer{}, false
+		}
+	}
+
+	return models.Observer{Latitude: lat, Longitude: lon, AltitudeM: alt}, true
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type QuarantineHandler struct {
+	quarantineService *services.QuarantineService
+}
+
+func NewQuarantineHandler(quarantineService *services.QuarantineService) *QuarantineHandler {
+	return &QuarantineHandler{
+		quarantineService: quarantineService,
+	}
+}
+
+// @Summary Get Quarantined Positions
+// @Description Lists upstream samples the collector refused to store because their altitude, velocity, timestamp or implied motion was physically impossible, newest first, with the failed rule and the reason
+// @Security ApiKeyAuth
+// @Tags ISS (Admin)
+// @Produce json
+// @Param status query string false "Only samples with this review status" Enums(pending, approved, rejected)
+// @Param limit query int false "Maximum number of samples (default 100, max 1000)"
+// @Success 200 {array} models.QuarantinedPosition
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/quarantine [get]
+func (h *QuarantineHandler) HandleGetQuarantined(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid limit", err.Error())
+		return
+	}
+
+	samples, err := h.quarantineService.GetQuarantined(r.URL.Query().Get("status"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidReview) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid status", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get quarantined positions", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, samples)
+}
+
+// @Summary Approve Quarantined Position
+// @Description Marks a pending sample as genuine and adds it to the stored positions
+// @Security ApiKeyAuth
+// @Tags ISS (Admin)
+// @Produce json
+// @Param id path int true "Quarantined position ID"
+// @Success 200 {object} models.QuarantinedPosition
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/quarantine/{id}/approve [post]
+func (h *QuarantineHandler) HandleApprove(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, true)
+}
+
+// @Summary Reject Quarantined Position
+// @Description Marks a pending sample as bogus; it stays in quarantine for the record
+// @Security ApiKeyAuth
+// @Tags ISS (Admin)
+// @Produce json
+// @Param id path int true "Quarantined position ID"
+// @Success 200 {object} models.QuarantinedPosition
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 409 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/quarantine/{id}/reject [post]
+func (h *QuarantineHandler) HandleReject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, false)
+}
+
+func (h *QuarantineHandler) review(w http.ResponseWriter, r *http.Request, approve bool) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	sample, err := h.quarantineService.Review(uint(id), approve)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrQuarantineNotFound):
+			utils.SendErrorResponse(w, http.StatusNotFound, "Quarantined position not found", err.Error())
+		case errors.Is(err, services.ErrAlreadyReviewed):
+			utils.SendErrorResponse(w, http.StatusConflict, "Already reviewed", err.Error())
+		default:
+			utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to review quarantined position", err.Error())
+		}
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, sample)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+// GetSAAStatus reports South Atlantic Anomaly status and upcoming passes
+// @Summary Get SAA Status
+// @Description Returns whether the ISS is inside the South Atlantic Anomaly and predicts its SAA passes over the next hours by propagating the current orbit (two-body + J2, no drag)
+// @Tags ISS
+// @Produce json
+// @Param hours query int false "Prediction horizon in hours (default 6, max 24)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {object} models.SAAStatusResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/saa [get]
+func (h *ISSHandler) GetSAAStatus(w http.ResponseWriter, r *http.Request) {
+	hours := services.SAA_DEFAULT_HOURS
+	if hoursStr := r.URL.Query().Get("hours"); hoursStr != "" {
+		parsed, err := strconv.Atoi(hoursStr)
+		if err != nil || parsed <= 0 || parsed > services.SAA_MAX_HOURS {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid hours", "hours must be between 1 and 24")
+			return
+		}
+		hours = parsed
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	status, err := issService.GetSAAStatus(hours)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get SAA status", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, status)
+}
+
+// GetSAABoundary returns the SAA boundary polygon
+// @Summary Get SAA Boundary
+// @Description Returns the embedded South Atlantic Anomaly boundary as a GeoJSON FeatureCollection with simplestyle fill properties, for use as a map overlay
+// @Tags ISS
+// @Produce json
+// @Success 200 {object} models.GeoJSONFeatureCollection
+// @Router /iss/saa/boundary [get]
+func (h *ISSHandler) GetSAABoundary(w http.ResponseWriter, r *http.Request) {
+	utils.SendJSONResponse(w, http.StatusOK, models.NewGeoJSONFeatureCollection(
+		[]models.GeoJSONFeature{services.SAABoundaryFeature()},
+	))
+}
+
+// GetGroundTrack returns the stored ground track as GeoJSON
+// @Summary Get ISS Ground Track
+// @Description Returns stored positions in a time range as GeoJSON LineStrings split at the antimeridian and at SAA boundary crossings (property in_saa), plus the SAA boundary as a shaded polygon
+// @Tags ISS
+// @Produce json
+// @Param start_time query int true "Start timestamp (Unix)"
+// @Param end_time query int true "End timestamp (Unix)"
+// @Success 200 {object} models.GeoJSONFeatureCollection
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/ground-track [get]
+func (h *ISSHandler) GetGroundTrack(w http.ResponseWriter, r *http.Request) {
+	startTime, errStart := strconv.ParseInt(r.URL.Query().Get("start_time"), 10, 64)
+	endTime, errEnd := strconv.ParseInt(r.URL.Query().Get("end_time"), 10, 64)
+	if errStart != nil || errEnd != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Missing parameters", "Both start_time and end_time are required Unix timestamps")
+		return
+	}
+
+	if startTime >= endTime {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time range", "start_time must be less than end_time")
+		return
+	}
+
+	if endTime-startTime > 24*3600 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Time range too large", "Maximum time range is 24 hours")
+		return
+	}
+
+	track, err := h.issService.GetGroundTrack(startTime, endTime)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get ground track", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, track)
+}
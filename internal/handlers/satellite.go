@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SatelliteHandler serves the satellite catalog and the per-satellite
+// tracking routes, which the ISS handler answers for the satellite in the
+// path. /iss/* are the same routes for NORAD 25544.
+type SatelliteHandler struct {
+	satelliteService *services.SatelliteService
+	issHandler       *ISSHandler
+}
+
+func NewSatelliteHandler(satelliteService *services.SatelliteService, issHandler *ISSHandler) *SatelliteHandler {
+	return &SatelliteHandler{
+		satelliteService: satelliteService,
+		issHandler:       issHandler,
+	}
+}
+
+type SatelliteRequest struct {
+	NoradID            int    `json:"norad_id" example:"48274"`
+	Name               string `json:"name" example:"CSS (TIANHE)"`
+	CollectionInterval int    `json:"collection_interval" example:"60"`
+	Active             *bool  `json:"active,omitempty"`
+}
+
+func (req *SatelliteRequest) toSatellite() *models.Satellite {
+	return &models.Satellite{
+		NoradID:            req.NoradID,
+		Name:               req.Name,
+		CollectionInterval: req.CollectionInterval,
+		Active:             req.Active == nil || *req.Active,
+	}
+}
+
+// @Summary Get Satellites
+// @Description Returns the satellite catalog ordered by NORAD catalog number
+// @Tags Satellites
+// @Produce json
+// @Success 200 {array} models.Satellite
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites [get]
+func (h *SatelliteHandler) HandleGetSatellites(w http.ResponseWriter, r *http.Request) {
+	satellites, err := h.satelliteService.GetSatellites()
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get satellites", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, satellites)
+}
+
+// @Summary Get Satellite
+// @Tags Satellites
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Success 200 {object} models.Satellite
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites/{norad} [get]
+func (h *SatelliteHandler) HandleGetSatellite(w http.ResponseWriter, r *http.Request) {
+	noradID, ok := parseNoradID(w, r)
+	if !ok {
+		return
+	}
+
+	satellite, err := h.satelliteService.GetSatellite(noradID)
+	if err != nil {
+		h.sendError(w, err, "Failed to get satellite")
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, satellite)
+}
+
+// @Summary Get Current Satellite Position
+// @Description Returns the current position of a satellite in the catalog, like /iss/current does for the ISS
+// @Tags Satellites
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Param units query string false "Units (kilometers or miles)" Enums(kilometers, miles) default(kilometers)
+// @Param frame query string false "Add a Cartesian state vector in this frame (eci is an alias of j2000)" Enums(ecef, eci, teme, j2000)
+// @Success 200 {object} models.ISSPositionWithState
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites/{norad}/current [get]
+func (h *SatelliteHandler) HandleGetCurrent(w http.ResponseWriter, r *http.Request) {
+	h.issHandler.GetCurrentPosition(w, r)
+}
+
+// @Summary Get Historical Satellite Position
+// @Description Returns a satellite's position for a specific timestamp (within 4 hours back/forward)
+// @Tags Satellites
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Param timestamp path int true "Unix timestamp"
+// @Param units query string false "Units (kilometers or miles)" Enums(kilometers, miles) default(kilometers)
+// @Param frame query string false "Add a Cartesian state vector in this frame (eci is an alias of j2000)" Enums(ecef, eci, teme, j2000)
+// @Success 200 {object} models.ISSPositionWithState
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites/{norad}/historical/{timestamp} [get]
+func (h *SatelliteHandler) HandleGetHistorical(w http.ResponseWriter, r *http.Request) {
+	h.issHandler.GetHistoricalPosition(w, r)
+}
+
+// @Summary Get Historical Satellite Position (POST)
+// @Tags Satellites
+// @Accept json
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Param request body models.HistoricalRequest true "Historical position request"
+// @Success 200 {object} models.ISSPositionWithState
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites/{norad}/historical [post]
+func (h *SatelliteHandler) HandlePostHistorical(w http.ResponseWriter, r *http.Request) {
+	h.issHandler.PostHistoricalRequest(w, r)
+}
+
+// @Summary Get Historical Satellite Positions in Batch
+// @Description Returns a satellite's positions for up to 500 timestamps, like /iss/historical/batch does for the ISS
+// @Tags Satellites
+// @Accept json
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Param request body models.HistoricalBatchRequest true "Historical batch request"
+// @Success 200 {object} models.HistoricalBatchResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /satellites/{norad}/historical/batch [post]
+func (h *SatelliteHandler) HandlePostHistoricalBatch(w http.ResponseWriter, r *http.Request) {
+	h.issHandler.PostHistoricalBatch(w, r)
+}
+
+// @Summary Get Satellite Positions in Time Range
+// @Tags Satellites
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Param start_time query int true "Start timestamp (Unix)"
+// @Param end_time query int true "End timestamp (Unix)"
+// @Param units query string false "Units (kilometers or miles)" Enums(kilometers, miles) default(kilometers)
+// @Param frame query string false "Add a Cartesian state vector in this frame (eci is an alias of j2000)" Enums(ecef, eci, teme, j2000)
+// @Success 200 {array} models.ISSPositionWithState
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites/{norad}/range [get]
+func (h *SatelliteHandler) HandleGetRange(w http.ResponseWriter, r *http.Request) {
+	h.issHandler.GetPositionsInRange(w, r)
+}
+
+// @Summary Get Satellite Tracking Status
+// @Tags Satellites
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /satellites/{norad}/status [get]
+func (h *SatelliteHandler) HandleGetStatus(w http.ResponseWriter, r *http.Request) {
+	h.issHandler.GetISSStatus(w, r)
+}
+
+// @Summary Create Satellite
+// @Description Adds a satellite to the catalog. Active satellites (the default) are collected every collection_interval seconds (default 60, min 10). The position API is asked for the satellite first, and satellites it doesn't serve are rejected
+// @Security ApiKeyAuth
+// @Tags Satellites (Admin)
+// @Accept json
+// @Produce json
+// @Param request body SatelliteRequest true "Satellite data"
+// @Success 201 {object} models.Satellite
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/satellites [post]
+func (h *SatelliteHandler) HandleCreateSatellite(w http.ResponseWriter, r *http.Request) {
+	var req SatelliteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	satellite, err := h.satelliteService.CreateSatellite(req.toSatellite())
+	if err != nil {
+		h.sendError(w, err, "Failed to create satellite")
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusCreated, satellite)
+}
+
+// @Summary Update Satellite
+// @Description Renames a satellite or changes its collection schedule. The ISS can't be deactivated
+// @Security ApiKeyAuth
+// @Tags Satellites (Admin)
+// @Accept json
+// @Produce json
+// @Param norad path int true "NORAD catalog number"
+// @Param request body SatelliteRequest true "Satellite data"
+// @Success 200 {object} models.Satellite
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/satellites/{norad} [put]
+func (h *SatelliteHandler) HandleUpdateSatellite(w http.ResponseWriter, r *http.Request) {
+	noradID, ok := parseNoradID(w, r)
+	if !ok {
+		return
+	}
+
+	var req SatelliteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	satellite, err := h.satelliteService.UpdateSatellite(noradID, req.toSatellite())
+	if err != nil {
+		h.sendError(w, err, "Failed to update satellite")
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, satellite)
+}
+
+// @Summary Delete Satellite
+// @Description Removes a satellite from the catalog and stops collecting it. The ISS can't be removed
+// @Security ApiKeyAuth
+// @Tags Satellites (Admin)
+// @Param norad path int true "NORAD catalog number"
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/satellites/{norad} [delete]
+func (h *SatelliteHandler) HandleDeleteSatellite(w http.ResponseWriter, r *http.Request) {
+	noradID, ok := parseNoradID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.satelliteService.DeleteSatellite(noradID); err != nil {
+		h.sendError(w, err, "Failed to delete satellite")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SatelliteHandler) sendError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrSatelliteNotFound):
+		utils.SendErrorResponse(w, http.StatusNotFound, "Satellite not found", err.Error())
+	case errors.Is(err, services.ErrInvalidSatellite):
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid satellite", err.Error())
+	case errors.Is(err, services.ErrUpstreamUnavailable), errors.Is(err, services.ErrUpstreamThrottled):
+		utils.SendErrorResponse(w, http.StatusServiceUnavailable, "Upstream API unavailable", err.Error())
+	default:
+		utils.SendErrorResponse(w, http.StatusInternalServerError, message, err.Error())
+	}
+}
+
+// parseNoradID reads the norad path parameter. On invalid input it writes a
+// 400 response and returns false.
+func parseNoradID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	noradID, err := strconv.Atoi(chi.URLParam(r, "norad"))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid NORAD ID", "norad must be a NORAD catalog number")
+		return 0, false
+	}
+	return noradID, true
+}
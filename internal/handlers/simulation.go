@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+// parseSimClock reads the optional sim_start (Unix seconds) and sim_speed
+// query parameters. It returns a nil clock when neither is set. On invalid
+// input it writes a 400 response and returns false.
+func parseSimClock(w http.ResponseWriter, r *http.Request) (*services.SimClock, bool) {
+	startStr := r.URL.Query().Get("sim_start")
+	speedStr := r.URL.Query().Get("sim_speed")
+	if startStr == "" && speedStr == "" {
+		return nil, true
+	}
+
+	var start time.Time
+	if startStr != "" {
+		seconds, err := strconv.ParseInt(startStr, 10, 64)
+		if err != nil || seconds <= 0 {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid sim_start", "sim_start must be a valid Unix timestamp")
+			return nil, false
+		}
+		start = time.Unix(seconds, 0)
+	}
+
+	speed := 1.0
+	if speedStr != "" {
+		var err error
+		speed, err = strconv.ParseFloat(speedStr, 64)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid sim_speed", "sim_speed must be a number")
+			return nil, false
+		}
+	}
+
+	clock, err := services.NewSimClock(start, speed)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid simulation", err.Error())
+		return nil, false
+	}
+
+	return clock, true
+}
+
+// serviceFor returns the request's satellite service scoped to its
+// simulation clock, or the shared service when no simulation was requested.
+func (h *ISSHandler) serviceFor(w http.ResponseWriter, r *http.Request) (*services.ISSService, bool) {
+	service, ok := h.satelliteFor(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	clock, ok := parseSimClock(w, r)
+	if !ok {
+		return nil, false
+	}
+	if clock == nil {
+		return service, true
+	}
+
+	return service.WithClock(clock), true
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+// GetSnapshot renders the ISS position on a world map
+// @Summary Get Map Snapshot
+// @Description Renders an equirectangular world map as PNG with the ISS, its visibility footprint, the last orbit of stored ground track and day/night shading. Everything is drawn server-side from embedded coastlines, so the image can be used directly in link previews.
+// @Tags ISS
+// @Produce png
+// @Param time query int false "Unix timestamp (default: now)"
+// @Param width query int false "Image width in pixels (256-2048)" default(1024)
+// @Param height query int false "Image height in pixels (128-1024, default: width/2)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/snapshot.png [get]
+func (h *ISSHandler) GetSnapshot(w http.ResponseWriter, r *http.Request) {
+	timestamp, ok := parseSnapshotTime(w, r)
+	if !ok {
+		return
+	}
+
+	width := services.SNAPSHOT_DEFAULT_WIDTH
+	if widthStr := r.URL.Query().Get("width"); widthStr != "" {
+		parsed, err := strconv.Atoi(widthStr)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid width", "width must be an integer")
+			return
+		}
+		width = parsed
+	}
+
+	height := width / 2
+	if heightStr := r.URL.Query().Get("height"); heightStr != "" {
+		parsed, err := strconv.Atoi(heightStr)
+		if err != nil {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid height", "height must be an integer")
+			return
+		}
+		height = parsed
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	image, err := issService.RenderSnapshot(timestamp, width, height)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSnapshot) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid size", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to render snapshot", err.Error())
+		return
+	}
+
+	sendPNG(w, image, timestamp)
+}
+
+// GetSocialCard renders an Open Graph card of the ISS position
+// @Summary Get Social Card
+// @Description Renders a 1200x630 PNG for Open Graph and Discord link previews: the snapshot map centered on the ISS with a caption naming the country or ocean below it, its coordinates, altitude and the time
+// @Tags ISS
+// @Produce png
+// @Param time query int false "Unix timestamp (default: now)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Success 200 {file} binary
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/snapshot/card.png [get]
+func (h *ISSHandler) GetSocialCard(w http.ResponseWriter, r *http.Request) {
+	timestamp, ok := parseSnapshotTime(w, r)
+	if !ok {
+		return
+	}
+
+	issService, ok := h.serviceFor(w, r)
+	if !ok {
+		return
+	}
+
+	image, err := issService.RenderSocialCard(timestamp)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to render social card", err.Error())
+		return
+	}
+
+	sendPNG(w, image, timestamp)
+}
+
+// parseSnapshotTime reads the "time" query parameter like
+// parseOptionalTimestamp reads "timestamp".
+func parseSnapshotTime(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	timeStr := r.URL.Query().Get("time")
+	if timeStr == "" {
+		return 0, true
+	}
+
+	timestamp, err := strconv.ParseInt(timeStr, 10, 64)
+	if err != nil || timestamp <= 0 {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time", "time must be a valid Unix timestamp")
+		return 0, false
+	}
+
+	return timestamp, true
+}
+
+// sendPNG writes a rendered image. Images of a fixed time never change, while
+// the current position is only worth caching for one collector interval.
+func sendPNG(w http.ResponseWriter, image []byte, timestamp int64) {
+	w.Header().Set("Content-Type", "image/png")
+	if timestamp != 0 {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=10")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	WS_WRITE_WAIT  = 10 * time.Second
+	WS_PONG_WAIT   = 60 * time.Second
+	WS_PING_PERIOD = WS_PONG_WAIT * 9 / 10
+
+	SIM_TICK_INTERVAL = 2 * time.Second
+
+	REPLAY_PAGE_SIZE = 500
+	REPLAY_MAX_WAIT  = 10 * time.Second // caps pauses over gaps in the archive
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	// CORS already allows any origin, the stream is public read-only data.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type StreamHandler struct {
+	hub        *services.EventHub
+	issService *services.ISSService
+}
+
+func NewStreamHandler(hub *services.EventHub, issService *services.ISSService) *StreamHandler {
+	return &StreamHandler{
+		hub:        hub,
+		issService: issService,
+	}
+}
+
+// HandleLive streams live events over a WebSocket
+// @Summary Live Event Stream (WebSocket)
+// @Description Upgrades to a WebSocket and pushes every live event as JSON: {"type": ..., "timestamp": ..., "data": ...}. Event types: position, orbit, geofence, crew_change, post_published.
+// @Description With mode=replay the session re-emits stored positions between start and end, with their original spacing divided by speed, plus the orbit events between them. A final replay_end event is sent before the server closes the connection.
+// @Description With sim_start or sim_speed the session runs on its own simulated clock instead: every 2 seconds it pushes the position at simulated time, plus orbit events derived from consecutive simulated positions.
+// @Tags Stream
+// @Param types query string false "Comma-separated list of event types to receive (default: all)"
+// @Param sim_start query int false "Simulate time starting at this Unix timestamp"
+// @Param sim_speed query number false "Simulation speed multiplier (default 1, max 3600)"
+// @Param mode query string false "live (default) or replay" Enums(live, replay)
+// @Param start query int false "Replay: first stored timestamp to emit (Unix)"
+// @Param end query int false "Replay: last stored timestamp to emit (Unix)"
+// @Param speed query number false "Replay: playback speed multiplier (default 1, max 3600)"
+// @Success 101 "Switching Protocols"
+// @Failure 400 {object} models.ErrorResponse
+// @Router /iss/live [get]
+func (h *StreamHandler) HandleLive(w http.ResponseWriter, r *http.Request) {
+	types := parseEventTypes(r.URL.Query().Get("types"))
+
+	clock, ok := parseSimClock(w, r)
+	if !ok {
+		return
+	}
+
+	replay, ok := parseReplay(w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	if replay != nil {
+		h.streamReplay(conn, replay, types)
+		return
+	}
+
+	if clock != nil {
+		h.streamSimulation(conn, h.issService.WithClock(clock), types)
+		return
+	}
+
+	events, unsubscribe := h.hub.Subscribe()
+	defer unsubscribe()
+
+	closed := watchClose(conn)
+
+	ticker := time.NewTicker(WS_PING_PERIOD)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if types != nil && !types[event.Type] {
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(WS_WRITE_WAIT))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(WS_WRITE_WAIT))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// streamSimulation pushes positions at the session's simulated time. Events
+// that only exist in real time (crew changes, posts, geofence alerts) are
+// not simulated.
+func (h *StreamHandler) streamSimulation(conn *websocket.Conn, issService *services.ISSService, types map[string]bool) {
+	closed := watchClose(conn)
+
+	simTicker := time.NewTicker(SIM_TICK_INTERVAL)
+	defer simTicker.Stop()
+
+	pingTicker := time.NewTicker(WS_PING_PERIOD)
+	defer pingTicker.Stop()
+
+	var prev *models.ISSPosition
+	tick := func() error {
+		position, err := issService.GetCurrentPosition("kilometers")
+		if err != nil {
+			log.Printf("Simulated position failed: %v", err)
+			return nil
+		}
+
+		if err := writePositionEvents(conn, types, prev, position); err != nil {
+			return err
+		}
+
+		prev = position
+		return nil
+	}
+
+	if tick() != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-simTicker.C:
+			if tick() != nil {
+				return
+			}
+		case <-pingTicker.C:
+			conn.SetWriteDeadline(time.Now().Add(WS_WRITE_WAIT))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+type replayRequest struct {
+	start int64
+	end   int64
+	speed float64
+}
+
+// parseReplay reads the replay parameters. It returns nil unless
+// mode=replay. On invalid input it writes a 400 response and returns false.
+func parseReplay(w http.ResponseWriter, r *http.Request) (*replayRequest, bool) {
+	query := r.URL.Query()
+	if query.Get("mode") != "replay" {
+		return nil, true
+	}
+
+	start, errStart := strconv.ParseInt(query.Get("start"), 10, 64)
+	end, errEnd := strconv.ParseInt(query.Get("end"), 10, 64)
+	if errStart != nil || errEnd != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Missing parameters", "Replay needs start and end as Unix timestamps")
+		return nil, false
+	}
+	if start >= end {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time range", "start must be less than end")
+		return nil, false
+	}
+
+	speed := 1.0
+	if speedStr := query.Get("speed"); speedStr != "" {
+		var err error
+		speed, err = strconv.ParseFloat(speedStr, 64)
+		if err != nil || speed <= 0 || speed > services.SIM_MAX_SPEED {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid speed", "speed must be between 0 and 3600")
+			return nil, false
+		}
+	}
+
+	return &replayRequest{start: start, end: end, speed: speed}, true
+}
+
+// streamReplay re-emits stored positions, paging through the archive.
+func (h *StreamHandler) streamReplay(conn *websocket.Conn, replay *replayRequest, types map[string]bool) {
+	closed := watchClose(conn)
+
+	pingTicker := time.NewTicker(WS_PING_PERIOD)
+	defer pingTicker.Stop()
+
+	// wait sleeps for d while keeping the connection alive. It returns false
+	// once the client is gone.
+	wait := func(d time.Duration) bool {
+		timer := time.NewTimer(min(d, REPLAY_MAX_WAIT))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return false
+			case <-timer.C:
+				return true
+			case <-pingTicker.C:
+				conn.SetWriteDeadline(time.Now().Add(WS_WRITE_WAIT))
+				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return false
+				}
+			}
+		}
+	}
+
+	var prev *models.ISSPosition
+	after := replay.start - 1
+
+	for {
+		page, err := h.issService.GetPositionsAfter(after, replay.end, REPLAY_PAGE_SIZE)
+		if err != nil {
+			log.Printf("Replay query failed: %v", err)
+			return
+		}
+
+		for _, position := range page {
+			if prev != nil {
+				gap := time.Duration(position.Timestamp-prev.Timestamp) * time.Second
+				if !wait(time.Duration(float64(gap) / replay.speed)) {
+					return
+				}
+			}
+			if err := writePositionEvents(conn, types, prev, position); err != nil {
+				return
+			}
+			prev = position
+		}
+
+		if len(page) < REPLAY_PAGE_SIZE {
+			break
+		}
+		after = page[len(page)-1].Timestamp
+	}
+
+	end := models.LiveEvent{Type: models.LiveEventReplayEnd, Timestamp: replay.end}
+	if writeEvent(conn, nil, end) != nil {
+		return
+	}
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "replay finished"),
+		time.Now().Add(WS_WRITE_WAIT))
+}
+
+// writeEvent sends event unless the client filtered its type out.
+func writeEvent(conn *websocket.Conn, types map[string]bool, event models.LiveEvent) error {
+	if types != nil && !types[event.Type] {
+		return nil
+	}
+	conn.SetWriteDeadline(time.Now().Add(WS_WRITE_WAIT))
+	return conn.WriteJSON(event)
+}
+
+// writePositionEvents sends curr and the orbit events since prev.
+func writePositionEvents(conn *websocket.Conn, types map[string]bool, prev, curr *models.ISSPosition) error {
+	err := writeEvent(conn, types, models.LiveEvent{Type: models.LiveEventPosition, Timestamp: curr.Timestamp, Data: curr})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range services.OrbitEventsBetween(prev, curr) {
+		err := writeEvent(conn, types, models.LiveEvent{Type: models.LiveEventOrbit, Timestamp: event.Timestamp, Data: event})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// watchClose drains incoming frames so pongs and close frames are processed,
+// and closes the returned channel once the client goes away.
+func watchClose(conn *websocket.Conn) <-chan struct{} {
+	closed := make(chan struct{})
+
+	conn.SetReadDeadline(time.Now().Add(WS_PONG_WAIT))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(WS_PONG_WAIT))
+	})
+
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	return closed
+}
+
+func parseEventTypes(raw string) map[string]bool {
+	if raw == "" {
+		return nil
+	}
+
+	types := make(map[string]bool)
+	for _, t := range strings.Split(raw, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = true
+		}
+	}
+	return types
+}
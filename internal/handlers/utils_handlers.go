@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+)
+
+type UtilsHandler struct{}
+
+func NewUtilsHandler() *UtilsHandler {
+	return &UtilsHandler{}
+}
+
+// GetTimeConversion converts an instant between time scales
+// @Summary Convert Time Scales
+// @Description Reads an instant on one time scale and returns it as UTC, Unix, TAI, TT, GPS (seconds, week and seconds of week), Julian dates and Greenwich mean/apparent sidereal time. Leap seconds come from a built-in table.
+// @Tags Utils
+// @Produce json
+// @Param value query string false "Instant to convert (default: now). RFC 3339 or Unix seconds for utc, YYYY-MM-DDThh:mm:ss for tai/tt, seconds since 1980-01-06 for gps, days for jd/mjd/jd_tt"
+// @Param scale query string false "Scale of value" Enums(utc, unix, tai, tt, gps, jd, mjd, jd_tt) default(utc)
+// @Success 200 {object} models.TimeConversionResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Router /utils/time [get]
+func (h *UtilsHandler) GetTimeConversion(w http.ResponseWriter, r *http.Request) {
+	t, err := services.ParseTime(r.URL.Query().Get("value"), r.URL.Query().Get("scale"))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTime) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid time", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to convert time", err.Error())
+		return
+	}
+
+	utils.SendJSONResponse(w, http.StatusOK, services.ConvertTime(t))
+}
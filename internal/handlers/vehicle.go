@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type VehicleHandler struct {
+	vehicleService *services.VehicleService
+}
+
+func NewVehicleHandler(vehicleService *services.VehicleService) *VehicleHandler {
+	return &VehicleHandler{
+		vehicleService: vehicleService,
+	}
+}
+
+type VehicleRequest struct {
+	Name          string     `json:"name" example:"Crew-9 Freedom"`
+	VehicleType   string     `json:"vehicle_type" enums:"dragon,soyuz,progress,cygnus,starliner,htv,dream_chaser,other"`
+	Description   string     `json:"description"`
+	ArrivalAt     time.Time  `json:"arrival_at"`
+	DepartureAt   *time.Time `json:"departure_at"`
+	ModuleID      uint       `json:"module_id"`
+	Port          string     `json:"port" example:"zenith"`
+	ModelAssetURL string     `json:"model_asset_url"`
+}
+
+func (req *VehicleRequest) toVehicle() *models.VisitingVehicle {
+	return &models.VisitingVehicle{
+		Name:          req.Name,
+		VehicleType:   req.VehicleType,
+		Description:   req.Description,
+		ArrivalAt:     req.ArrivalAt,
+		DepartureAt:   req.DepartureAt,
+		ModuleID:      req.ModuleID,
+		Port:          req.Port,
+		ModelAssetURL: req.ModelAssetURL,
+	}
+}
+
+// @Summary Get Visiting Vehicles
+// @Description Returns visiting vehicles, most recent arrival first
+// @Tags Vehicles
+// @Produce json
+// @Param timestamp query int false "Only vehicles attached at this Unix timestamp"
+// @Success 200 {array} models.VisitingVehicle
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/vehicles [get]
+func (h *VehicleHandler) HandleGetAllVehicles(w http.ResponseWriter, r *http.Request) {
+	timestamp, ok := parseOptionalTimestamp(w, r)
+	if !ok {
+		return
+	}
+
+	vehicles, err := h.vehicleService.GetAllVehicles(timestamp)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get vehicles", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, vehicles)
+}
+
+// @Summary Get Visiting Vehicle by ID
+// @Tags Vehicles
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Success 200 {object} models.VisitingVehicle
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Router /iss/vehicles/{id} [get]
+func (h *VehicleHandler) HandleGetVehicleByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	vehicle, err := h.vehicleService.GetVehicleByID(uint(id))
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusNotFound, "Vehicle not found", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, vehicle)
+}
+
+// @Summary Get Docking Port Occupancy
+// @Description Returns every docking port from the module catalog with the vehicle attached to it at the given time
+// @Tags Vehicles
+// @Produce json
+// @Param timestamp query int false "Unix timestamp (default: now)"
+// @Success 200 {object} models.PortOccupancyResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /iss/ports/occupancy [get]
+func (h *VehicleHandler) HandleGetPortOccupancy(w http.ResponseWriter, r *http.Request) {
+	timestamp, ok := parseOptionalTimestamp(w, r)
+	if !ok {
+		return
+	}
+
+	occupancy, err := h.vehicleService.GetPortOccupancy(timestamp)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get port occupancy", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, occupancy)
+}
+
+// @Summary Create Visiting Vehicle
+// @Security ApiKeyAuth
+// @Tags Vehicles (Admin)
+// @Accept json
+// @Produce json
+// @Param request body VehicleRequest true "Vehicle data"
+// @Success 201 {object} models.VisitingVehicle
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/vehicles [post]
+func (h *VehicleHandler) HandleCreateVehicle(w http.ResponseWriter, r *http.Request) {
+	var req VehicleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	vehicle, err := h.vehicleService.CreateVehicle(req.toVehicle())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidVehicle) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid vehicle", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to create vehicle", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusCreated, vehicle)
+}
+
+// @Summary Update Visiting Vehicle
+// @Security ApiKeyAuth
+// @Tags Vehicles (Admin)
+// @Accept json
+// @Produce json
+// @Param id path int true "Vehicle ID"
+// @Param request body VehicleRequest true "Vehicle data"
+// @Success 200 {object} models.VisitingVehicle
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/iss/vehicles/{id} [put]
+func (h *VehicleHandler) HandleUpdateVehicle(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req VehicleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	vehicle, err := h.vehicleService.UpdateVehicle(uint(id), req.toVehicle())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidVehicle) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid vehicle", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to update vehicle", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, vehicle)
+}
+
+// @Summary Delete Visiting Vehicle
+// @Security ApiKeyAuth
+// @Tags Vehicles (Admin)
+// @Param id path int true "Vehicle ID"
+// @Success 204 "No Content"
+// @Router /admin/iss/vehicles/{id} [delete]
+func (h *VehicleHandler) HandleDeleteVehicle(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.vehicleService.DeleteVehicle(uint(id)); err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to delete vehicle", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"iss-model-backend/internal/models"
+	"iss-model-backend/internal/services"
+	"iss-model-backend/internal/utils"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+type WebhookRequest struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types" enums:"position,orbit,geofence,crew_change,post_published"`
+	Active     *bool    `json:"active"`
+}
+
+func (req *WebhookRequest) toSubscription() *models.WebhookSubscription {
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return &models.WebhookSubscription{
+		Name:       req.Name,
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		Active:     active,
+	}
+}
+
+// @Summary Get All Webhooks
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) HandleGetAllWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookService.GetAllSubscriptions()
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get webhooks", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, subscriptions)
+}
+
+// @Summary Create Webhook
+// @Description Registers an endpoint for the given event types. Deliveries are signed with HMAC-SHA256 over "<X-Webhook-Timestamp>.<body>" and sent in the X-Webhook-Signature header. A secret is generated when none is given; it is only returned in this response.
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Accept json
+// @Produce json
+// @Param request body WebhookRequest true "Webhook data"
+// @Success 201 {object} models.WebhookSubscriptionWithSecret
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) HandleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(req.toSubscription())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWebhook) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid webhook", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to create webhook", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusCreated, models.WebhookSubscriptionWithSecret{
+		WebhookSubscription: *subscription,
+		Secret:              subscription.Secret,
+	})
+}
+
+// @Summary Update Webhook
+// @Description Replaces the webhook settings. An empty secret keeps the current one.
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Accept json
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param request body WebhookRequest true "Webhook data"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webhooks/{id} [put]
+func (h *WebhookHandler) HandleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(uint(id), req.toSubscription())
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWebhook) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid webhook", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to update webhook", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, subscription)
+}
+
+// @Summary Delete Webhook
+// @Description Deletes the webhook together with its delivery log
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Param id path int true "Webhook ID"
+// @Success 204 "No Content"
+// @Router /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) HandleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(uint(id)); err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to delete webhook", err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary Get Webhook Delivery Log
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Produce json
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Delivery status" Enums(pending, delivered, dead)
+// @Param limit query int false "Maximum number of deliveries (max 500)" default(100)
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) HandleGetDeliveries(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid limit", err.Error())
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(uint(id), r.URL.Query().Get("status"), limit)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get deliveries", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, deliveries)
+}
+
+// @Summary Get Dead-Lettered Deliveries
+// @Description Returns deliveries that failed after all retry attempts
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Produce json
+// @Param limit query int false "Maximum number of deliveries (max 500)" default(100)
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webhooks/dead-letters [get]
+func (h *WebhookHandler) HandleGetDeadLetters(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid limit", err.Error())
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeadLetters(limit)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to get dead letters", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, deliveries)
+}
+
+// @Summary Retry Dead-Lettered Delivery
+// @Security ApiKeyAuth
+// @Tags Webhooks (Admin)
+// @Produce json
+// @Param id path int true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Router /admin/webhooks/deliveries/{id}/retry [post]
+func (h *WebhookHandler) HandleRetryDelivery(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		utils.SendErrorResponse(w, http.StatusBadRequest, "Invalid ID", err.Error())
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(uint(id))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidWebhook) {
+			utils.SendErrorResponse(w, http.StatusBadRequest, "Delivery cannot be retried", err.Error())
+			return
+		}
+		utils.SendErrorResponse(w, http.StatusInternalServerError, "Failed to retry delivery", err.Error())
+		return
+	}
+	utils.SendJSONResponse(w, http.StatusOK, delivery)
+}
+
+func parseLimit(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(v)
+}
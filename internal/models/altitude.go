@@ -0,0 +1,51 @@
+package models
+
+// OrbitAltitude is the mean altitude over one revolution, from one ascending
+// node to the next. Averaging over a whole orbit removes the swing that the
+// Earth's flattening and the orbit's eccentricity put into single samples,
+// and the rows outlive the raw positions they were computed from.
+type OrbitAltitude struct {
+	ID             uint    `json:"id" gorm:"primaryKey"`
+	StartTimestamp int64   `json:"start_timestamp" gorm:"not null;uniqueIndex"`
+	EndTimestamp   int64   `json:"end_timestamp" gorm:"not null"`
+	MeanAltitudeKm float64 `json:"mean_altitude_km" gorm:"type:decimal(10,5);not null"`
+	Samples        int     `json:"samples" gorm:"not null"`
+}
+
+func (OrbitAltitude) TableName() string {
+	return "orbit_altitudes"
+}
+
+// AltitudeTrendResponse is a linear fit of the per-orbit mean altitude since
+// the last reboost in the requested window. A positive decay means the ISS
+// is losing altitude.
+type AltitudeTrendResponse struct {
+	From                 int64           `json:"from"`
+	To                   int64           `json:"to"`
+	FitFrom              int64           `json:"fit_from"`
+	Orbits               int             `json:"orbits"`
+	CurrentAltitudeKm    float64         `json:"current_altitude_km"`
+	DecayMetersPerDay    float64         `json:"decay_m_per_day"`
+	DecayStdErrPerDay    float64         `json:"decay_std_err_m_per_day"`
+	ResidualStdDevMeters float64         `json:"residual_std_dev_m"`
+	Reboosts             []MissionEvent  `json:"reboosts"`
+	Series               []OrbitAltitude `json:"series"`
+}
+
+// AltitudeForecastPoint is a predicted altitude with its 95% prediction
+// interval.
+type AltitudeForecastPoint struct {
+	Timestamp  int64   `json:"timestamp"`
+	AltitudeKm float64 `json:"altitude_km"`
+	LowerKm    float64 `json:"lower_km"`
+	UpperKm    float64 `json:"upper_km"`
+}
+
+type AltitudeForecastResponse struct {
+	FitFrom           int64                   `json:"fit_from"`
+	Orbits            int                     `json:"orbits"`
+	DecayMetersPerDay float64                 `json:"decay_m_per_day"`
+	DecayStdErrPerDay float64                 `json:"decay_std_err_m_per_day"`
+	Days              int                     `json:"days"`
+	Points            []AltitudeForecastPoint `json:"points"`
+}
@@ -0,0 +1,40 @@
+package models
+
+type Vector3 struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+type Quaternion struct {
+	W float64 `json:"w"`
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+type AttitudeResponse struct {
+	Timestamp      int64      `json:"timestamp"`
+	Mode           string     `json:"mode"`
+	ReferenceFrame string     `json:"reference_frame"`
+	Quaternion     Quaternion `json:"quaternion"`
+	NadirBody      Vector3    `json:"nadir_body"`
+	VelocityBody   Vector3    `json:"velocity_body"`
+	SunBody        Vector3    `json:"sun_body"`
+	Latitude       float64    `json:"latitude"`
+	Longitude      float64    `json:"longitude"`
+	Altitude       float64    `json:"altitude"`
+	Visibility     string     `json:"visibility"`
+}
+
+// GimbalAnglesResponse holds the nominal sun-tracking joint angles in degrees.
+// Both SARJs share Alpha and every BGA shares Beta in the nominal solution.
+type GimbalAnglesResponse struct {
+	Timestamp      int64   `json:"timestamp"`
+	SARJAlpha      float64 `json:"sarj_alpha"`
+	BGABeta        float64 `json:"bga_beta"`
+	TRRJ           float64 `json:"trrj"`
+	SolarBetaAngle float64 `json:"solar_beta_angle"`
+	SunBody        Vector3 `json:"sun_body"`
+	Visibility     string  `json:"visibility"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// CoverageCell is the time the ground track spent in one grid cell on one
+// UTC day. Cells are indexed from the south-west corner (-90°, -180°).
+type CoverageCell struct {
+	ID       uint    `json:"id" gorm:"primaryKey"`
+	Day      string  `json:"day" gorm:"size:10;not null;uniqueIndex:idx_coverage_day_cell"`
+	LatIndex int     `json:"lat_index" gorm:"not null;uniqueIndex:idx_coverage_day_cell"`
+	LonIndex int     `json:"lon_index" gorm:"not null;uniqueIndex:idx_coverage_day_cell"`
+	Seconds  float64 `json:"seconds" gorm:"not null;default:0"`
+}
+
+// CoverageProgress is the single row holding the timestamp of the last
+// position added to the coverage grid, so positions are backfilled after a
+// restart without counting any segment twice.
+type CoverageProgress struct {
+	ID        uint  `json:"id" gorm:"primaryKey"`
+	Timestamp int64 `json:"timestamp" gorm:"not null"`
+}
+
+func (CoverageProgress) TableName() string {
+	return "coverage_progress"
+}
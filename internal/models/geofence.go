@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+)
+
+const (
+	GeofenceShapeCircle  = "circle"
+	GeofenceShapePolygon = "polygon"
+
+	GeofenceEventEnter = "enter"
+	GeofenceEventExit  = "exit"
+)
+
+// Coordinates is a ring of [longitude, latitude] pairs stored as jsonb,
+// using the same axis order as GeoJSON.
+type Coordinates [][2]float64
+
+func (c *Coordinates) Scan(value interface{}) error {
+	if value == nil {
+		*c = Coordinates{}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	}
+
+	return nil
+}
+
+func (c Coordinates) Value() (driver.Value, error) {
+	if len(c) == 0 {
+		return "[]", nil
+	}
+	return json.Marshal(c)
+}
+
+type Geofence struct {
+	ID          uint        `json:"id" gorm:"primaryKey"`
+	Name        string      `json:"name" gorm:"size:100;not null;uniqueIndex"`
+	Description string      `json:"description" gorm:"type:text"`
+	Shape       string      `json:"shape" gorm:"size:20;not null"`
+	CenterLat   float64     `json:"center_lat,omitempty" gorm:"type:decimal(10,8)"`
+	CenterLon   float64     `json:"center_lon,omitempty" gorm:"type:decimal(11,8)"`
+	RadiusKm    float64     `json:"radius_km,omitempty" gorm:"type:decimal(10,3)"`
+	Polygon     Coordinates `json:"polygon,omitempty" gorm:"type:jsonb"`
+	Active      bool        `json:"active" gorm:"not null;default:true"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+func (Geofence) TableName() string {
+	return "geofences"
+}
+
+type GeofenceEvent struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	GeofenceID   uint      `json:"geofence_id" gorm:"index;not null"`
+	GeofenceName string    `json:"geofence_name" gorm:"size:100;not null"`
+	EventType    string    `json:"event_type" gorm:"size:10;not null"`
+	Latitude     float64   `json:"latitude" gorm:"type:decimal(10,8);not null"`
+	Longitude    float64   `json:"longitude" gorm:"type:decimal(11,8);not null"`
+	Timestamp    int64     `json:"timestamp" gorm:"index;not null"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (GeofenceEvent) TableName() string {
+	return "geofence_events"
+}
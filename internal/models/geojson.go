@@ -0,0 +1,47 @@
+package models
+
+const (
+	GeoJSONFeatureCollectionType = "FeatureCollection"
+	GeoJSONFeatureType           = "Feature"
+	GeoJSONPoint                 = "Point"
+	GeoJSONLineString            = "LineString"
+	GeoJSONPolygon               = "Polygon"
+	GeoJSONMultiPolygon          = "MultiPolygon"
+)
+
+// GeoJSONGeometry holds any RFC 7946 geometry. Coordinates are [lon, lat].
+type GeoJSONGeometry struct {
+	Type        string `json:"type"`
+	Coordinates any    `json:"coordinates" swaggertype:"array,number"`
+}
+
+type GeoJSONFeature struct {
+	Type       string          `json:"type"`
+	Geometry   GeoJSONGeometry `json:"geometry"`
+	Properties map[string]any  `json:"properties"`
+}
+
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+func NewGeoJSONFeature(geometryType string, coordinates any, properties map[string]any) GeoJSONFeature {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+
+	return GeoJSONFeature{
+		Type:       GeoJSONFeatureType,
+		Geometry:   GeoJSONGeometry{Type: geometryType, Coordinates: coordinates},
+		Properties: properties,
+	}
+}
+
+func NewGeoJSONFeatureCollection(features []GeoJSONFeature) *GeoJSONFeatureCollection {
+	if features == nil {
+		features = []GeoJSONFeature{}
+	}
+
+	return &GeoJSONFeatureCollection{Type: GeoJSONFeatureCollectionType, Features: features}
+}
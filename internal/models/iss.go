@@ -4,22 +4,25 @@ import (
 	"time"
 )
 
+// ISSPosition is a collected position of the satellite SatelliteID, the ISS
+// for rows stored before other satellites were tracked.
 type ISSPosition struct {
-	ID         uint      `json:"id" gorm:"primaryKey"`
-	Name       string    `json:"name" gorm:"size:50;not null"`
-	Latitude   float64   `json:"latitude" gorm:"type:decimal(10,8);not null"`
-	Longitude  float64   `json:"longitude" gorm:"type:decimal(11,8);not null"`
-	Altitude   float64   `json:"altitude" gorm:"type:decimal(10,5);not null"`
-	Velocity   float64   `json:"velocity" gorm:"type:decimal(12,6);not null"`
-	Visibility string    `json:"visibility" gorm:"size:20;not null"`
-	Footprint  float64   `json:"footprint" gorm:"type:decimal(10,4);not null"`
-	Timestamp  int64     `json:"timestamp" gorm:"uniqueIndex;not null"`
-	Daynum     float64   `json:"daynum" gorm:"type:decimal(15,7);not null"`
-	SolarLat   float64   `json:"solar_lat" gorm:"type:decimal(10,8);not null"`
-	SolarLon   float64   `json:"solar_lon" gorm:"type:decimal(11,8);not null"`
-	Units      string    `json:"units" gorm:"size:20;not null"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	SatelliteID int       `json:"satellite_id" gorm:"not null;default:25544;uniqueIndex:idx_iss_positions_satellite_timestamp,priority:1"`
+	Name        string    `json:"name" gorm:"size:50;not null"`
+	Latitude    float64   `json:"latitude" gorm:"type:decimal(10,8);not null"`
+	Longitude   float64   `json:"longitude" gorm:"type:decimal(11,8);not null"`
+	Altitude    float64   `json:"altitude" gorm:"type:decimal(10,5);not null"`
+	Velocity    float64   `json:"velocity" gorm:"type:decimal(12,6);not null"`
+	Visibility  string    `json:"visibility" gorm:"size:20;not null"`
+	Footprint   float64   `json:"footprint" gorm:"type:decimal(10,4);not null"`
+	Timestamp   int64     `json:"timestamp" gorm:"not null;uniqueIndex:idx_iss_positions_satellite_timestamp,priority:2"`
+	Daynum      float64   `json:"daynum" gorm:"type:decimal(15,7);not null"`
+	SolarLat    float64   `json:"solar_lat" gorm:"type:decimal(10,8);not null"`
+	SolarLon    float64   `json:"solar_lon" gorm:"type:decimal(11,8);not null"`
+	Units       string    `json:"units" gorm:"size:20;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 func (ISSPosition) TableName() string {
@@ -49,21 +52,71 @@ type SolarAngleResponse struct {
 type HistoricalRequest struct {
 	Timestamp int64  `json:"timestamp" validate:"required"`
 	Units     string `json:"units,omitempty"`
+	Frame     string `json:"frame,omitempty" enums:"ecef,eci,teme,j2000"`
+}
+
+const (
+	HistoricalSourceStored       = "stored"
+	HistoricalSourceInterpolated = "interpolated"
+	HistoricalSourceUpstream     = "upstream"
+)
+
+// HistoricalBatchRequest asks for the positions at several timestamps at
+// once, e.g. the keyframes of a whole orbit.
+type HistoricalBatchRequest struct {
+	Timestamps []int64 `json:"timestamps" validate:"required"`
+	Units      string  `json:"units,omitempty"`
+	Frame      string  `json:"frame,omitempty" enums:"ecef,eci,teme,j2000"`
+}
+
+// HistoricalBatchPosition is the position at one requested timestamp. Source
+// says whether it is a stored sample, interpolated between stored samples or
+// fetched from wheretheiss.at.
+type HistoricalBatchPosition struct {
+	ISSPositionWithState
+	Source string `json:"source" enums:"stored,interpolated,upstream"`
+}
+
+// HistoricalBatchResponse lists the positions in request order.
+type HistoricalBatchResponse struct {
+	Positions     []HistoricalBatchPosition `json:"positions"`
+	UpstreamCalls int                       `json:"upstream_calls"`
+}
+
+// StateVector is a Cartesian position and velocity in an Earth-centered
+// frame. "eci" requests are reported as "j2000".
+type StateVector struct {
+	Frame        string  `json:"frame"`
+	Epoch        int64   `json:"epoch"`
+	Position     Vector3 `json:"position"`
+	Velocity     Vector3 `json:"velocity"`
+	PositionUnit string  `json:"position_unit"`
+	VelocityUnit string  `json:"velocity_unit"`
+}
+
+// ISSPositionWithState is a position plus, when a frame was requested, its
+// state vector.
+type ISSPositionWithState struct {
+	ISSPosition
+	InSAA       bool         `json:"in_saa"`
+	NearestCity *NearbyPlace `json:"nearest_city,omitempty"`
+	State       *StateVector `json:"state,omitempty"`
 }
 
 func (r *ISSPositionResponse) ToISSPosition() *ISSPosition {
 	return &ISSPosition{
-		Name:       r.Name,
-		Latitude:   r.Latitude,
-		Longitude:  r.Longitude,
-		Altitude:   r.Altitude,
-		Velocity:   r.Velocity,
-		Visibility: r.Visibility,
-		Footprint:  r.Footprint,
-		Timestamp:  r.Timestamp,
-		Daynum:     r.Daynum,
-		SolarLat:   r.SolarLat,
-		SolarLon:   r.SolarLon,
-		Units:      r.Units,
+		SatelliteID: r.ID,
+		Name:        r.Name,
+		Latitude:    r.Latitude,
+		Longitude:   r.Longitude,
+		Altitude:    r.Altitude,
+		Velocity:    r.Velocity,
+		Visibility:  r.Visibility,
+		Footprint:   r.Footprint,
+		Timestamp:   r.Timestamp,
+		Daynum:      r.Daynum,
+		SolarLat:    r.SolarLat,
+		SolarLon:    r.SolarLon,
+		Units:       r.Units,
 	}
 }
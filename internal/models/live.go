@@ -0,0 +1,52 @@
+package models
+
+const (
+	LiveEventPosition      = "position"
+	LiveEventOrbit         = "orbit"
+	LiveEventGeofence      = "geofence"
+	LiveEventCrewChange    = "crew_change"
+	LiveEventPostPublished = "post_published"
+
+	// LiveEventReplayEnd closes a replay session; it is never published on
+	// the hub.
+	LiveEventReplayEnd = "replay_end"
+
+	OrbitEventEclipseEntry   = "eclipse_entry"
+	OrbitEventEclipseExit    = "eclipse_exit"
+	OrbitEventAscendingNode  = "ascending_node"
+	OrbitEventDescendingNode = "descending_node"
+	OrbitEventSAAEntry       = "saa_entry"
+	OrbitEventSAAExit        = "saa_exit"
+)
+
+// LiveEventTypes lists every event type published on the event hub.
+var LiveEventTypes = []string{
+	LiveEventPosition,
+	LiveEventOrbit,
+	LiveEventGeofence,
+	LiveEventCrewChange,
+	LiveEventPostPublished,
+}
+
+// LiveEvent is the envelope pushed to live subscribers of the event hub.
+type LiveEvent struct {
+	Type      string `json:"type"`
+	Timestamp int64  `json:"timestamp"`
+	Data      any    `json:"data"`
+}
+
+// OrbitEvent marks an eclipse transition, an equator crossing or a South
+// Atlantic Anomaly boundary crossing detected between two consecutive
+// collected positions.
+type OrbitEvent struct {
+	EventType string  `json:"event_type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timestamp int64   `json:"timestamp"`
+}
+
+type CrewChange struct {
+	Added   []string    `json:"added"`
+	Removed []string    `json:"removed"`
+	People  []Astronaut `json:"people"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MissionEventEVA        = "eva"
+	MissionEventDocking    = "docking"
+	MissionEventUndocking  = "undocking"
+	MissionEventReboost    = "reboost"
+	MissionEventLaunch     = "launch"
+	MissionEventExperiment = "experiment"
+)
+
+var MissionEventTypes = []string{
+	MissionEventEVA,
+	MissionEventDocking,
+	MissionEventUndocking,
+	MissionEventReboost,
+	MissionEventLaunch,
+	MissionEventExperiment,
+}
+
+// MissionEvent is an entry of the curated mission timeline. EndAt is nil for
+// events without a meaningful duration, such as a docking. Crew holds
+// astronaut names as listed by /iss/crew. DeltaVMs is the velocity change
+// of a reboost in m/s, when known.
+type MissionEvent struct {
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	Type        string            `json:"type" gorm:"size:30;not null;index"`
+	Title       string            `json:"title" gorm:"size:255;not null"`
+	Description string            `json:"description" gorm:"type:text"`
+	StartAt     time.Time         `json:"start_at" gorm:"not null;index"`
+	EndAt       *time.Time        `json:"end_at" gorm:"index"`
+	Crew        StringArray       `json:"crew" gorm:"type:jsonb"`
+	DeltaVMs    *float64          `json:"delta_v_ms,omitempty"`
+	Modules     []Module          `json:"modules" gorm:"many2many:mission_event_modules"`
+	Vehicles    []VisitingVehicle `json:"vehicles" gorm:"many2many:mission_event_vehicles"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt    `json:"-" gorm:"index"`
+}
+
+func (MissionEvent) TableName() string {
+	return "mission_events"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Dimensions are the module's outer extents in meters.
+type Dimensions struct {
+	LengthM float64 `json:"length_m"`
+	WidthM  float64 `json:"width_m"`
+	HeightM float64 `json:"height_m"`
+}
+
+// Transform places a module in the 3D model's coordinate system.
+type Transform struct {
+	Position Vector3    `json:"position" gorm:"embedded;embeddedPrefix:position_"`
+	Rotation Quaternion `json:"rotation" gorm:"embedded;embeddedPrefix:rotation_"`
+	Scale    float64    `json:"scale" gorm:"default:1"`
+}
+
+// Module names are unique among modules that aren't deleted, so a deleted
+// module's name can be reused.
+type Module struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	Name           string         `json:"name" gorm:"size:100;not null;uniqueIndex:idx_modules_name_active,where:deleted_at IS NULL"`
+	Description    string         `json:"description" gorm:"type:text"`
+	LaunchDate     *time.Time     `json:"launch_date"`
+	MassKg         float64        `json:"mass_kg" gorm:"type:decimal(12,2)"`
+	Dimensions     Dimensions     `json:"dimensions" gorm:"embedded;embeddedPrefix:dimensions_"`
+	Agency         string         `json:"agency" gorm:"size:50"`
+	AttachmentPort string         `json:"attachment_port" gorm:"size:100"`
+	DockingPorts   StringArray    `json:"docking_ports" gorm:"type:jsonb"`
+	Transform      Transform      `json:"transform" gorm:"embedded;embeddedPrefix:transform_"`
+	ModelAssetURL  string         `json:"model_asset_url" gorm:"size:500"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (Module) TableName() string {
+	return "modules"
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+const (
+	RegionTypeCountry = "country"
+	RegionTypeOcean   = "ocean"
+	RegionTypeSea     = "sea"
+
+	OverflightPeriodDay  = "day"
+	OverflightPeriodWeek = "week"
+	OverflightPeriodAll  = "all"
+)
+
+// Region is what lies under the ISS: a country (ISO 3166-1 alpha-2 code) or
+// a named body of water.
+type Region struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Type string `json:"type" enums:"country,ocean,sea"`
+}
+
+// OverflightDay is the time the ISS spent over one region on one UTC day.
+type OverflightDay struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	Day        string    `json:"day" gorm:"size:10;not null;uniqueIndex:idx_overflight_day_region"`
+	RegionCode string    `json:"region_code" gorm:"size:40;not null;uniqueIndex:idx_overflight_day_region;index"`
+	RegionName string    `json:"region_name" gorm:"size:100;not null"`
+	RegionType string    `json:"region_type" gorm:"size:20;not null"`
+	Seconds    float64   `json:"seconds" gorm:"not null;default:0"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type OverflightTotal struct {
+	Rank    int     `json:"rank"`
+	Region  Region  `json:"region"`
+	Seconds float64 `json:"seconds"`
+	Hours   float64 `json:"hours"`
+	Percent float64 `json:"percent"`
+}
+
+type OverflightRankingResponse struct {
+	Period       string            `json:"period" enums:"day,week,all"`
+	From         string            `json:"from,omitempty"`
+	To           string            `json:"to"`
+	TotalSeconds float64           `json:"total_seconds"`
+	Regions      []OverflightTotal `json:"regions"`
+}
+
+type OverflightDayValue struct {
+	Day     string  `json:"day"`
+	Seconds float64 `json:"seconds"`
+}
+
+// OverflightSeriesResponse is one region's daily overflight time, with days
+// without an overflight filled in as zero.
+type OverflightSeriesResponse struct {
+	Region       Region               `json:"region"`
+	TotalSeconds float64              `json:"total_seconds"`
+	Days         []OverflightDayValue `json:"days"`
+}
@@ -0,0 +1,54 @@
+package models
+
+// Observer is a place on the ground that passes are predicted for.
+type Observer struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	AltitudeM float64 `json:"altitude_m"`
+}
+
+// SkyPoint is the position of the ISS in an observer's sky. Azimuth is
+// measured clockwise from true north.
+type SkyPoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Azimuth   float64 `json:"azimuth"`
+	Elevation float64 `json:"elevation"`
+	RangeKm   float64 `json:"range_km"`
+	Sunlit    bool    `json:"sunlit"`
+}
+
+// ObserverPass is a predicted pass of the ISS above an observer's horizon:
+// acquisition of signal (AOS), time of closest approach (TCA, the highest
+// point) and loss of signal (LOS). A pass is visible when the ISS is sunlit
+// while the observer's sky is dark.
+type ObserverPass struct {
+	AOS             SkyPoint   `json:"aos"`
+	TCA             SkyPoint   `json:"tca"`
+	LOS             SkyPoint   `json:"los"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Visible         bool       `json:"visible"`
+	SkyPlotURL      string     `json:"sky_plot_url"`
+	Path            []SkyPoint `json:"path"`
+}
+
+type ObserverPassesResponse struct {
+	Observer     Observer       `json:"observer"`
+	From         int64          `json:"from"`
+	Hours        int            `json:"hours"`
+	MinElevation float64        `json:"min_elevation"`
+	Passes       []ObserverPass `json:"passes"`
+}
+
+// CalendarPass is a pass issued in a calendar feed. Later predictions of the
+// same pass drift by seconds to minutes, so they are matched to it by TCA
+// and keep its UID.
+type CalendarPass struct {
+	ID       uint   `json:"id" gorm:"primaryKey"`
+	Observer string `json:"observer" gorm:"size:32;not null;index:idx_calendar_pass_observer_tca"`
+	TCA      int64  `json:"tca" gorm:"not null;index:idx_calendar_pass_observer_tca"`
+	UID      string `json:"uid" gorm:"size:100;not null;uniqueIndex"`
+}
+
+func (CalendarPass) TableName() string {
+	return "calendar_passes"
+}
@@ -0,0 +1,44 @@
+package models
+
+// NearbyPlace is a city relative to the ISS sub-satellite point. Bearing is
+// measured from the sub-satellite point towards the city.
+type NearbyPlace struct {
+	Name        string  `json:"name"`
+	CountryCode string  `json:"country_code"`
+	Country     string  `json:"country"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Population  int64   `json:"population"`
+	DistanceKm  float64 `json:"distance_km"`
+	BearingDeg  float64 `json:"bearing_deg"`
+}
+
+type Landmark struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Kind        string  `json:"kind"`
+	CountryCode string  `json:"country_code"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+// LandmarkOverflight is a predicted pass of the ISS within the search
+// radius of a landmark. Closest approach is sampled on the propagation step,
+// so times are good to about 10 seconds.
+type LandmarkOverflight struct {
+	Landmark            Landmark `json:"landmark"`
+	StartTime           int64    `json:"start_time"`
+	EndTime             int64    `json:"end_time"`
+	ClosestApproachTime int64    `json:"closest_approach_time"`
+	MinDistanceKm       float64  `json:"min_distance_km"`
+	ISSLatitude         float64  `json:"iss_latitude"`
+	ISSLongitude        float64  `json:"iss_longitude"`
+	BearingDeg          float64  `json:"bearing_deg"`
+}
+
+type LandmarkOverflightsResponse struct {
+	From        int64                `json:"from"`
+	Hours       int                  `json:"hours"`
+	RadiusKm    float64              `json:"radius_km"`
+	Overflights []LandmarkOverflight `json:"overflights"`
+}
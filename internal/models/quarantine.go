@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+const (
+	QuarantineStatusPending  = "pending"
+	QuarantineStatusApproved = "approved"
+	QuarantineStatusRejected = "rejected"
+
+	QuarantineRuleRange      = "range"
+	QuarantineRuleTimestamp  = "timestamp"
+	QuarantineRuleSpeed      = "speed"
+	QuarantineRuleAltitude   = "altitude_jump"
+	QuarantineRulePropagated = "propagated_orbit"
+)
+
+// QuarantinedPosition is an upstream sample the collector refused to store
+// because it is physically implausible. Rule names the failed check and
+// Reason gives the numbers. Approving a sample copies it into iss_positions.
+type QuarantinedPosition struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	SatelliteID int        `json:"satellite_id" gorm:"not null;default:25544"`
+	Name        string     `json:"name" gorm:"size:50;not null"`
+	Latitude    float64    `json:"latitude" gorm:"type:decimal(10,8);not null"`
+	Longitude   float64    `json:"longitude" gorm:"type:decimal(11,8);not null"`
+	Altitude    float64    `json:"altitude" gorm:"type:decimal(10,5);not null"`
+	Velocity    float64    `json:"velocity" gorm:"type:decimal(12,6);not null"`
+	Visibility  string     `json:"visibility" gorm:"size:20;not null"`
+	Footprint   float64    `json:"footprint" gorm:"type:decimal(10,4);not null"`
+	Timestamp   int64      `json:"timestamp" gorm:"not null;index"`
+	Daynum      float64    `json:"daynum" gorm:"type:decimal(15,7);not null"`
+	SolarLat    float64    `json:"solar_lat" gorm:"type:decimal(10,8);not null"`
+	SolarLon    float64    `json:"solar_lon" gorm:"type:decimal(11,8);not null"`
+	Units       string     `json:"units" gorm:"size:20;not null"`
+	Rule        string     `json:"rule" gorm:"size:30;not null;index"`
+	Reason      string     `json:"reason" gorm:"type:text;not null"`
+	Status      string     `json:"status" gorm:"size:20;not null;default:pending;index"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (QuarantinedPosition) TableName() string {
+	return "quarantined_positions"
+}
+
+// Position returns the sample as it would have been stored.
+func (q *QuarantinedPosition) Position() *ISSPosition {
+	return &ISSPosition{
+		SatelliteID: q.SatelliteID,
+		Name:        q.Name,
+		Latitude:    q.Latitude,
+		Longitude:   q.Longitude,
+		Altitude:    q.Altitude,
+		Velocity:    q.Velocity,
+		Visibility:  q.Visibility,
+		Footprint:   q.Footprint,
+		Timestamp:   q.Timestamp,
+		Daynum:      q.Daynum,
+		SolarLat:    q.SolarLat,
+		SolarLon:    q.SolarLon,
+		Units:       q.Units,
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+// SAAPass is a predicted transit through the South Atlantic Anomaly.
+type SAAPass struct {
+	EntryTime       int64   `json:"entry_time"`
+	ExitTime        int64   `json:"exit_time"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	EntryLatitude   float64 `json:"entry_latitude"`
+	EntryLongitude  float64 `json:"entry_longitude"`
+	ExitLatitude    float64 `json:"exit_latitude"`
+	ExitLongitude   float64 `json:"exit_longitude"`
+}
+
+type SAAStatusResponse struct {
+	Timestamp      int64     `json:"timestamp"`
+	InSAA          bool      `json:"in_saa"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	UpcomingPasses []SAAPass `json:"upcoming_passes"`
+}
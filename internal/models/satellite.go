@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Satellite is a tracked object in the catalog, keyed by its NORAD catalog
+// number. Active satellites are collected every CollectionInterval seconds.
+type Satellite struct {
+	NoradID            int       `json:"norad_id" gorm:"primaryKey;autoIncrement:false"`
+	Name               string    `json:"name" gorm:"size:100;not null"`
+	CollectionInterval int       `json:"collection_interval" gorm:"not null"`
+	Active             bool      `json:"active" gorm:"not null"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+func (Satellite) TableName() string {
+	return "satellites"
+}
@@ -0,0 +1,20 @@
+package models
+
+// TimeConversionResponse expresses one instant on several time scales. TAI
+// and TT are calendar readings of those scales, without a zone suffix.
+type TimeConversionResponse struct {
+	UTC               string  `json:"utc"`
+	Unix              float64 `json:"unix"`
+	TAI               string  `json:"tai"`
+	TT                string  `json:"tt"`
+	TAIMinusUTC       float64 `json:"tai_minus_utc"`
+	GPSSeconds        float64 `json:"gps_seconds"`
+	GPSWeek           int     `json:"gps_week"`
+	GPSSecondsOfWeek  float64 `json:"gps_seconds_of_week"`
+	JulianDateUTC     float64 `json:"jd_utc"`
+	JulianDateTT      float64 `json:"jd_tt"`
+	ModifiedJulianUTC float64 `json:"mjd_utc"`
+	JulianCenturiesTT float64 `json:"julian_centuries_tt"`
+	GMSTDegrees       float64 `json:"gmst_deg"`
+	GASTDegrees       float64 `json:"gast_deg"`
+}
@@ -0,0 +1,27 @@
+package models
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// UpstreamHostMetrics counts the outbound calls to one third-party host
+// since startup. Requests are calls made by the services; Attempts are the
+// HTTP requests actually sent, retries included.
+type UpstreamHostMetrics struct {
+	Host              string  `json:"host"`
+	RatePerSecond     float64 `json:"rate_per_second"`
+	Requests          int64   `json:"requests"`
+	Attempts          int64   `json:"attempts"`
+	CacheHits         int64   `json:"cache_hits"`
+	Revalidations     int64   `json:"revalidations"`
+	StaleServed       int64   `json:"stale_served"`
+	Retries           int64   `json:"retries"`
+	Failures          int64   `json:"failures"`
+	RateLimited       int64   `json:"rate_limited"`
+	ThrottledMs       int64   `json:"throttled_ms"`
+	BreakerState      string  `json:"breaker_state" enums:"closed,open,half_open"`
+	BreakerTrips      int64   `json:"breaker_trips"`
+	BreakerRejections int64   `json:"breaker_rejections"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var VehicleTypes = []string{
+	"dragon",
+	"soyuz",
+	"progress",
+	"cygnus",
+	"starliner",
+	"htv",
+	"dream_chaser",
+	"other",
+}
+
+type VisitingVehicle struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	Name          string         `json:"name" gorm:"size:100;not null"`
+	VehicleType   string         `json:"vehicle_type" gorm:"size:30;not null;index"`
+	Description   string         `json:"description" gorm:"type:text"`
+	ArrivalAt     time.Time      `json:"arrival_at" gorm:"not null;index"`
+	DepartureAt   *time.Time     `json:"departure_at" gorm:"index"`
+	ModuleID      uint           `json:"module_id" gorm:"not null;index"`
+	Module        *Module        `json:"module,omitempty" gorm:"foreignKey:ModuleID"`
+	Port          string         `json:"port" gorm:"size:50;not null"`
+	ModelAssetURL string         `json:"model_asset_url" gorm:"size:500"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+func (VisitingVehicle) TableName() string {
+	return "visiting_vehicles"
+}
+
+// PortOccupancy describes one docking port at a given time. Vehicle is nil
+// when the port is free.
+type PortOccupancy struct {
+	ModuleID   uint             `json:"module_id"`
+	ModuleName string           `json:"module_name"`
+	Port       string           `json:"port"`
+	Vehicle    *VisitingVehicle `json:"vehicle"`
+}
+
+type PortOccupancyResponse struct {
+	Timestamp int64           `json:"timestamp"`
+	Ports     []PortOccupancy `json:"ports"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+// WebhookSubscription is never serialized with its secret, which is only
+// returned once, on creation, as a WebhookSubscriptionWithSecret.
+type WebhookSubscription struct {
+	ID         uint        `json:"id" gorm:"primaryKey"`
+	Name       string      `json:"name" gorm:"size:100;not null"`
+	URL        string      `json:"url" gorm:"size:500;not null"`
+	Secret     string      `json:"-" gorm:"size:128;not null"`
+	EventTypes StringArray `json:"event_types" gorm:"type:jsonb"`
+	Active     bool        `json:"active" gorm:"not null;default:true"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+func (WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+type WebhookSubscriptionWithSecret struct {
+	WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+type WebhookDelivery struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint       `json:"subscription_id" gorm:"index;not null"`
+	EventType      string     `json:"event_type" gorm:"size:30;not null"`
+	Payload        string     `json:"payload" gorm:"type:text;not null"`
+	Status         string     `json:"status" gorm:"size:20;not null;index"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"index"`
+	ResponseStatus int        `json:"response_status"`
+	LastError      string     `json:"last_error" gorm:"type:text"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
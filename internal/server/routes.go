@@ -34,6 +34,7 @@ func (s *Server) RegisterRoutes() http.Handler {
 		r.Get("/historical/{timestamp}", s.issHandler.GetHistoricalPosition)
 
 		r.Post("/historical", s.issHandler.PostHistoricalRequest)
+		r.Post("/historical/batch", s.issHandler.PostHistoricalBatch)
 
 		r.Get("/range", s.issHandler.GetPositionsInRange)
 
@@ -44,6 +45,66 @@ func (s *Server) RegisterRoutes() http.Handler {
 		r.Get("/crewWithPhotos", s.crewHandler.GetCurrentCrewWithPhotos)
 
 		r.Get("/solar-angle", s.issHandler.GetSolarAngle)
+
+		r.Get("/live", s.streamHandler.HandleLive)
+
+		r.Get("/saa", s.issHandler.GetSAAStatus)
+		r.Get("/saa/boundary", s.issHandler.GetSAABoundary)
+		r.Get("/ground-track", s.issHandler.GetGroundTrack)
+		r.Get("/landmarks", s.issHandler.GetLandmarks)
+		r.Get("/landmarks/upcoming", s.issHandler.GetUpcomingLandmarks)
+		r.Get("/snapshot.png", s.issHandler.GetSnapshot)
+		r.Get("/snapshot/card.png", s.issHandler.GetSocialCard)
+		r.Get("/passes", s.issHandler.GetPasses)
+		r.Get("/passes/{aos}/sky.svg", s.issHandler.GetPassSkyPlot)
+		r.Get("/calendar.ics", s.calendarHandler.HandleGetFeed)
+
+		r.Get("/overflights", s.overflightHandler.HandleGetRanking)
+		r.Get("/overflights/{code}", s.overflightHandler.HandleGetSeries)
+
+		r.Get("/coverage", s.coverageHandler.HandleGetGrid)
+		r.Get("/coverage/{z}/{x}/{y}.png", s.coverageHandler.HandleGetTile)
+
+		r.Get("/altitude/trend", s.altitudeHandler.HandleGetTrend)
+		r.Get("/altitude/forecast", s.altitudeHandler.HandleGetForecast)
+
+		r.Get("/model/attitude", s.issHandler.GetAttitude)
+		r.Get("/model/gimbals", s.issHandler.GetGimbalAngles)
+
+		r.Get("/modules", s.moduleHandler.HandleGetAllModules)
+		r.Get("/modules/{id}", s.moduleHandler.HandleGetModuleByID)
+
+		r.Get("/vehicles", s.vehicleHandler.HandleGetAllVehicles)
+		r.Get("/vehicles/{id}", s.vehicleHandler.HandleGetVehicleByID)
+		r.Get("/ports/occupancy", s.vehicleHandler.HandleGetPortOccupancy)
+
+		r.Get("/events", s.missionEventHandler.HandleGetAllEvents)
+		r.Get("/events/{id}", s.missionEventHandler.HandleGetEventByID)
+
+		r.Get("/geofences", s.geofenceHandler.HandleGetAllGeofences)
+		r.Get("/geofences/events", s.geofenceHandler.HandleGetGeofenceEvents)
+	})
+
+	r.Route("/satellites", func(r chi.Router) {
+		r.Get("/", s.satelliteHandler.HandleGetSatellites)
+
+		r.Route("/{norad}", func(r chi.Router) {
+			r.Get("/", s.satelliteHandler.HandleGetSatellite)
+			r.Get("/current", s.satelliteHandler.HandleGetCurrent)
+			r.Get("/historical/{timestamp}", s.satelliteHandler.HandleGetHistorical)
+			r.Post("/historical", s.satelliteHandler.HandlePostHistorical)
+			r.Post("/historical/batch", s.satelliteHandler.HandlePostHistoricalBatch)
+			r.Get("/range", s.satelliteHandler.HandleGetRange)
+			r.Get("/status", s.satelliteHandler.HandleGetStatus)
+		})
+	})
+
+	r.Route("/earth", func(r chi.Router) {
+		r.Get("/terminator", s.earthHandler.GetTerminator)
+	})
+
+	r.Route("/utils", func(r chi.Router) {
+		r.Get("/time", s.utilsHandler.GetTimeConversion)
 	})
 
 	r.Route("/blog", func(r chi.Router) {
@@ -61,6 +122,38 @@ func (s *Server) RegisterRoutes() http.Handler {
 			r.Post("/blog/posts", s.postHandler.HandleCreatePost)
 			r.Put("/blog/posts/{id}", s.postHandler.HandleUpdatePost)
 			r.Delete("/blog/posts/{id}", s.postHandler.HandleDeletePost)
+
+			r.Post("/geofences", s.geofenceHandler.HandleCreateGeofence)
+			r.Put("/geofences/{id}", s.geofenceHandler.HandleUpdateGeofence)
+			r.Delete("/geofences/{id}", s.geofenceHandler.HandleDeleteGeofence)
+
+			r.Post("/iss/modules", s.moduleHandler.HandleCreateModule)
+			r.Put("/iss/modules/{id}", s.moduleHandler.HandleUpdateModule)
+			r.Delete("/iss/modules/{id}", s.moduleHandler.HandleDeleteModule)
+
+			r.Post("/iss/vehicles", s.vehicleHandler.HandleCreateVehicle)
+			r.Put("/iss/vehicles/{id}", s.vehicleHandler.HandleUpdateVehicle)
+			r.Delete("/iss/vehicles/{id}", s.vehicleHandler.HandleDeleteVehicle)
+
+			r.Post("/iss/events", s.missionEventHandler.HandleCreateEvent)
+			r.Put("/iss/events/{id}", s.missionEventHandler.HandleUpdateEvent)
+			r.Delete("/iss/events/{id}", s.missionEventHandler.HandleDeleteEvent)
+
+			r.Get("/iss/quarantine", s.quarantineHandler.HandleGetQuarantined)
+			r.Post("/iss/quarantine/{id}/approve", s.quarantineHandler.HandleApprove)
+			r.Post("/iss/quarantine/{id}/reject", s.quarantineHandler.HandleReject)
+
+			r.Post("/satellites", s.satelliteHandler.HandleCreateSatellite)
+			r.Put("/satellites/{norad}", s.satelliteHandler.HandleUpdateSatellite)
+			r.Delete("/satellites/{norad}", s.satelliteHandler.HandleDeleteSatellite)
+
+			r.Get("/webhooks", s.webhookHandler.HandleGetAllWebhooks)
+			r.Post("/webhooks", s.webhookHandler.HandleCreateWebhook)
+			r.Get("/webhooks/dead-letters", s.webhookHandler.HandleGetDeadLetters)
+			r.Post("/webhooks/deliveries/{id}/retry", s.webhookHandler.HandleRetryDelivery)
+			r.Put("/webhooks/{id}", s.webhookHandler.HandleUpdateWebhook)
+			r.Delete("/webhooks/{id}", s.webhookHandler.HandleDeleteWebhook)
+			r.Get("/webhooks/{id}/deliveries", s.webhookHandler.HandleGetDeliveries)
 		})
 	})
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
@@ -95,15 +188,21 @@ func (s *Server) HelloWorldHandler(w http.ResponseWriter, r *http.Request) {
 
 // healthHandler returns the health status of the application
 // @Summary Health Check
-// @Description Returns the health status of the database and application
+// @Description Returns the health status of the database and application, with per-host counters of outbound API calls (cache hits, retries, rate limiting, circuit breaker state) under "upstream"
 // @Tags health
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]string
+// @Success 200 {object} map[string]interface{}
 // @Router /health [get]
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
+	health := make(map[string]any)
+	for key, value := range s.db.Health() {
+		health[key] = value
+	}
+	health["upstream"] = s.upstream.Metrics()
+
 	w.Header().Set("Content-Type", "application/json")
-	jsonResp, err := json.Marshal(s.db.Health())
+	jsonResp, err := json.Marshal(health)
 	if err != nil {
 		log.Printf("error handling JSON marshal. Err: %v", err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
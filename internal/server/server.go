@@ -18,15 +18,43 @@ import (
 type Server struct {
 	port int
 
-	db          database.Service
-	issService  *services.ISSService
-	issHandler  *handlers.ISSHandler
-	crewService *services.CrewService
-	crewHandler *handlers.CrewHandler
-	postService *services.PostService
-	postHandler *handlers.PostHandler
-	authService *services.AuthService
-	authHandler *handlers.AuthHandler
+	db                  database.Service
+	eventHub            *services.EventHub
+	upstream            *services.UpstreamClient
+	streamHandler       *handlers.StreamHandler
+	issService          *services.ISSService
+	issHandler          *handlers.ISSHandler
+	satelliteService    *services.SatelliteService
+	satelliteHandler    *handlers.SatelliteHandler
+	crewService         *services.CrewService
+	crewHandler         *handlers.CrewHandler
+	postService         *services.PostService
+	postHandler         *handlers.PostHandler
+	authService         *services.AuthService
+	authHandler         *handlers.AuthHandler
+	geofenceService     *services.GeofenceService
+	geofenceHandler     *handlers.GeofenceHandler
+	overflightService   *services.OverflightService
+	overflightHandler   *handlers.OverflightHandler
+	coverageService     *services.CoverageService
+	coverageHandler     *handlers.CoverageHandler
+	webhookService      *services.WebhookService
+	webhookHandler      *handlers.WebhookHandler
+	mqttPublisher       *services.MQTTPublisher
+	moduleService       *services.ModuleService
+	moduleHandler       *handlers.ModuleHandler
+	vehicleService      *services.VehicleService
+	vehicleHandler      *handlers.VehicleHandler
+	missionEventService *services.MissionEventService
+	missionEventHandler *handlers.MissionEventHandler
+	altitudeService     *services.AltitudeService
+	altitudeHandler     *handlers.AltitudeHandler
+	quarantineService   *services.QuarantineService
+	quarantineHandler   *handlers.QuarantineHandler
+	calendarService     *services.CalendarService
+	calendarHandler     *handlers.CalendarHandler
+	utilsHandler        *handlers.UtilsHandler
+	earthHandler        *handlers.EarthHandler
 }
 
 func NewServer() *http.Server {
@@ -39,31 +67,114 @@ func NewServer() *http.Server {
 
 	gormDB := dbService.GetDB()
 
-	err := gormDB.AutoMigrate(&models.ISSPosition{}, &models.Post{}, &models.User{})
+	err := gormDB.AutoMigrate(
+		&models.ISSPosition{},
+		&models.Post{},
+		&models.User{},
+		&models.Geofence{},
+		&models.GeofenceEvent{},
+		&models.WebhookSubscription{},
+		&models.WebhookDelivery{},
+		&models.Module{},
+		&models.VisitingVehicle{},
+		&models.MissionEvent{},
+		&models.OverflightDay{},
+		&models.CoverageCell{},
+		&models.CoverageProgress{},
+		&models.OrbitAltitude{},
+		&models.CalendarPass{},
+		&models.QuarantinedPosition{},
+		&models.Satellite{},
+	)
 	if err != nil {
 		fmt.Printf("Failed to auto-migrate models: %v\n", err)
 	}
 
-	issService := services.NewISSService(gormDB)
-	issHandler := handlers.NewISSHandler(issService)
-	crewService := services.NewCrewService()
+	eventHub := services.NewEventHub()
+	upstream := services.NewUpstreamClient(services.FixtureConfigFromEnv())
+	issService := services.NewISSService(gormDB, eventHub, upstream)
+	streamHandler := handlers.NewStreamHandler(eventHub, issService)
+	satelliteService := services.NewSatelliteService(gormDB, issService)
+	issHandler := handlers.NewISSHandler(issService, satelliteService)
+	satelliteHandler := handlers.NewSatelliteHandler(satelliteService, issHandler)
+	crewService := services.NewCrewService(eventHub, upstream)
 	crewHandler := handlers.NewCrewHandler(crewService)
-	postService := services.NewPostService(gormDB)
+	postService := services.NewPostService(gormDB, eventHub)
 	postHandler := handlers.NewPostHandler(postService)
 	authService := services.NewAuthService(gormDB)
 	authHandler := handlers.NewAuthHandler(authService)
+	geofenceService := services.NewGeofenceService(gormDB, eventHub)
+	geofenceHandler := handlers.NewGeofenceHandler(geofenceService)
+	overflightService := services.NewOverflightService(gormDB)
+	overflightHandler := handlers.NewOverflightHandler(overflightService)
+	coverageService := services.NewCoverageService(gormDB)
+	coverageHandler := handlers.NewCoverageHandler(coverageService)
+	webhookService := services.NewWebhookService(gormDB, eventHub)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	moduleService := services.NewModuleService(gormDB)
+	moduleHandler := handlers.NewModuleHandler(moduleService)
+	vehicleService := services.NewVehicleService(gormDB)
+	vehicleHandler := handlers.NewVehicleHandler(vehicleService)
+	missionEventService := services.NewMissionEventService(gormDB)
+	missionEventHandler := handlers.NewMissionEventHandler(missionEventService)
+	altitudeService := services.NewAltitudeService(gormDB, missionEventService)
+	altitudeHandler := handlers.NewAltitudeHandler(altitudeService)
+	quarantineService := services.NewQuarantineService(gormDB)
+	quarantineHandler := handlers.NewQuarantineHandler(quarantineService)
+	calendarService := services.NewCalendarService(gormDB, issService, vehicleService, missionEventService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	utilsHandler := handlers.NewUtilsHandler()
+	earthHandler := handlers.NewEarthHandler()
+
+	issService.OnNewPosition(geofenceService.CheckCrossing)
+	issService.OnNewPosition(overflightService.Accumulate)
+	issService.OnNewPosition(coverageService.Accumulate)
+	issService.OnNewPosition(altitudeService.Accumulate)
+
+	var mqttPublisher *services.MQTTPublisher
+	if mqttConfig := services.MQTTConfigFromEnv(); mqttConfig.BrokerURL != "" {
+		mqttPublisher = services.NewMQTTPublisher(mqttConfig, eventHub)
+	}
 
 	newServer := &Server{
-		port:        port,
-		db:          dbService,
-		issService:  issService,
-		issHandler:  issHandler,
-		crewService: crewService,
-		crewHandler: crewHandler,
-		postService: postService,
-		postHandler: postHandler,
-		authService: authService,
-		authHandler: authHandler,
+		port:                port,
+		db:                  dbService,
+		eventHub:            eventHub,
+		upstream:            upstream,
+		streamHandler:       streamHandler,
+		issService:          issService,
+		issHandler:          issHandler,
+		satelliteService:    satelliteService,
+		satelliteHandler:    satelliteHandler,
+		crewService:         crewService,
+		crewHandler:         crewHandler,
+		postService:         postService,
+		postHandler:         postHandler,
+		authService:         authService,
+		authHandler:         authHandler,
+		geofenceService:     geofenceService,
+		geofenceHandler:     geofenceHandler,
+		overflightService:   overflightService,
+		overflightHandler:   overflightHandler,
+		coverageService:     coverageService,
+		coverageHandler:     coverageHandler,
+		webhookService:      webhookService,
+		webhookHandler:      webhookHandler,
+		mqttPublisher:       mqttPublisher,
+		moduleService:       moduleService,
+		moduleHandler:       moduleHandler,
+		vehicleService:      vehicleService,
+		vehicleHandler:      vehicleHandler,
+		missionEventService: missionEventService,
+		missionEventHandler: missionEventHandler,
+		altitudeService:     altitudeService,
+		altitudeHandler:     altitudeHandler,
+		quarantineService:   quarantineService,
+		quarantineHandler:   quarantineHandler,
+		calendarService:     calendarService,
+		calendarHandler:     calendarHandler,
+		utilsHandler:        utilsHandler,
+		earthHandler:        earthHandler,
 	}
 
 	server := &http.Server{
@@ -0,0 +1,428 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ALTITUDE_MAX_GAP    = 60 // seconds; a revolution with a longer hole is not averaged
+	ALTITUDE_MIN_PERIOD = 85 * 60
+	ALTITUDE_MAX_PERIOD = 100 * 60
+	// ALTITUDE_RECENT_ORBITS is how many revolutions of raw positions the
+	// collector hook re-reads at each ascending node.
+	ALTITUDE_RECENT_ORBITS = 2
+
+	ALTITUDE_DEFAULT_TREND_DAYS    = 14
+	ALTITUDE_MAX_TREND_DAYS        = 365
+	ALTITUDE_DEFAULT_FORECAST_DAYS = 28
+	ALTITUDE_MAX_FORECAST_DAYS     = 90
+	ALTITUDE_MIN_FIT_ORBITS        = 8
+
+	// A reboost shows as the median of the ALTITUDE_REBOOST_WINDOW orbits
+	// after a revolution rising at least ALTITUDE_REBOOST_MIN_KM above the
+	// median of the ones before. Drag alone takes weeks to move the mean
+	// altitude that much.
+	ALTITUDE_REBOOST_WINDOW = 3
+	ALTITUDE_REBOOST_MIN_KM = 0.2
+
+	// Two-sided 95% quantile of the normal distribution.
+	ALTITUDE_FORECAST_Z = 1.96
+)
+
+var ErrInsufficientAltitudeData = errors.New("not enough altitude history")
+
+// decayFit is a least-squares line through per-orbit mean altitudes, in km
+// against Unix seconds.
+type decayFit struct {
+	n         int
+	meanX     float64
+	sxx       float64
+	intercept float64
+	slope     float64
+	residual  float64 // standard deviation of the residuals, km
+}
+
+func (f *decayFit) at(timestamp float64) float64 {
+	return f.intercept + f.slope*timestamp
+}
+
+// predictionInterval is the half-width of the 95% interval for a single
+// future orbit mean at timestamp.
+func (f *decayFit) predictionInterval(timestamp float64) float64 {
+	d := timestamp - f.meanX
+	return ALTITUDE_FORECAST_Z * f.residual * math.Sqrt(1+1/float64(f.n)+d*d/f.sxx)
+}
+
+func (f *decayFit) decayMetersPerDay() float64 {
+	return -f.slope * 86400 * 1000
+}
+
+func (f *decayFit) decayStdErrPerDay() float64 {
+	return f.residual / math.Sqrt(f.sxx) * 86400 * 1000
+}
+
+// detectedReboost is the revolution during which the mean altitude stepped up.
+type detectedReboost struct {
+	orbit  models.OrbitAltitude
+	stepKm float64
+}
+
+// AltitudeService keeps the per-orbit altitude history, fits the decay rate
+// and logs the reboosts it finds on the mission timeline. Raw positions only
+// last DATA_RETENTION_HOURS, so the orbit means are the long-term tier.
+type AltitudeService struct {
+	db                  *gorm.DB
+	missionEventService *MissionEventService
+}
+
+func NewAltitudeService(db *gorm.DB, missionEventService *MissionEventService) *AltitudeService {
+	service := &AltitudeService{db: db, missionEventService: missionEventService}
+
+	go service.backfill()
+
+	return service
+}
+
+// backfill averages the revolutions still in the raw position table, so the
+// history survives restarts and starts filling on first deployment.
+func (s *AltitudeService) backfill() {
+	var positions []*models.ISSPosition
+	if err := s.db.Where("satellite_id = ?", ISS_ID).Order("timestamp asc").Find(&positions).Error; err != nil {
+		log.Printf("Failed to load positions for altitude history: %v", err)
+		return
+	}
+	s.record(orbitAltitudes(positions))
+}
+
+// Accumulate is registered as a collector hook. At every ascending node it
+// averages the revolution that just ended.
+func (s *AltitudeService) Accumulate(prev, curr *models.ISSPosition) {
+	if prev == nil || !(prev.Latitude < 0 && curr.Latitude >= 0) {
+		return
+	}
+
+	since := curr.Timestamp - ALTITUDE_RECENT_ORBITS*ALTITUDE_MAX_PERIOD
+	var positions []*models.ISSPosition
+	if err := s.db.Where("satellite_id = ? AND timestamp >= ?", ISS_ID, since).Order("timestamp asc").Find(&positions).Error; err != nil {
+		log.Printf("Failed to load positions for altitude history: %v", err)
+		return
+	}
+	s.record(orbitAltitudes(positions))
+}
+
+// record stores new orbit means and looks for reboosts around them.
+func (s *AltitudeService) record(orbits []models.OrbitAltitude) {
+	var earliest int64
+	for _, orbit := range orbits {
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&orbit)
+		if result.Error != nil {
+			log.Printf("Failed to store orbit altitude: %v", result.Error)
+			return
+		}
+		if result.RowsAffected > 0 && earliest == 0 {
+			earliest = orbit.StartTimestamp
+		}
+	}
+	if earliest == 0 {
+		return
+	}
+
+	// A reboost at the edge of the new orbits is only confirmed by the
+	// window of orbits on either side, so reload those too.
+	margin := int64(ALTITUDE_REBOOST_WINDOW+1) * ALTITUDE_MAX_PERIOD
+	var series []models.OrbitAltitude
+	if err := s.db.Where("start_timestamp >= ?", earliest-margin).Order("start_timestamp asc").Find(&series).Error; err != nil {
+		log.Printf("Failed to load orbit altitudes: %v", err)
+		return
+	}
+
+	for _, reboost := range detectReboosts(series) {
+		if err := s.logReboost(reboost); err != nil {
+			log.Printf("Failed to log reboost: %v", err)
+		}
+	}
+}
+
+// logReboost adds a detected reboost to the mission timeline unless one is
+// already listed around that revolution, detected or entered by hand.
+func (s *AltitudeService) logReboost(reboost detectedReboost) error {
+	start := time.Unix(reboost.orbit.StartTimestamp, 0)
+	end := time.Unix(reboost.orbit.EndTimestamp, 0)
+
+	existing, err := s.missionEventService.GetEvents(MissionEventFilter{
+		Type: models.MissionEventReboost,
+		From: start.Add(-ALTITUDE_MAX_PERIOD * time.Second).Unix(),
+		To:   end.Add(ALTITUDE_MAX_PERIOD * time.Second).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	deltaV := math.Round(reboostDeltaV(reboost.orbit.MeanAltitudeKm, reboost.stepKm)*100) / 100
+	_, err = s.missionEventService.CreateEvent(&models.MissionEvent{
+		Type:  models.MissionEventReboost,
+		Title: fmt.Sprintf("Reboost +%.1f km", reboost.stepKm),
+		Description: fmt.Sprintf("Detected from a %.2f km step in the per-orbit mean altitude. Estimated delta-v %.2f m/s.",
+			reboost.stepKm, deltaV),
+		StartAt:  start,
+		EndAt:    &end,
+		DeltaVMs: &deltaV,
+	}, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Detected reboost of %.2f km during the orbit starting %s", reboost.stepKm, start.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// orbitAltitudes splits time-ordered positions at ascending nodes and
+// averages the altitude over every complete revolution. Node times are
+// interpolated between samples and the mean is weighted by time.
+func orbitAltitudes(positions []*models.ISSPosition) []models.OrbitAltitude {
+	var orbits []models.OrbitAltitude
+
+	inOrbit := false
+	var start, lastT, lastAlt, area float64
+	samples := 0
+
+	for i := 1; i < len(positions); i++ {
+		prev, curr := positions[i-1], positions[i]
+		dt := float64(curr.Timestamp - prev.Timestamp)
+		if dt <= 0 {
+			continue
+		}
+		if dt > ALTITUDE_MAX_GAP {
+			inOrbit = false
+			continue
+		}
+
+		if prev.Latitude < 0 && curr.Latitude >= 0 {
+			f := -prev.Latitude / (curr.Latitude - prev.Latitude)
+			nodeT := float64(prev.Timestamp) + f*dt
+			nodeAlt := prev.Altitude + f*(curr.Altitude-prev.Altitude)
+
+			if inOrbit {
+				area += (nodeT - lastT) * (lastAlt + nodeAlt) / 2
+				period := nodeT - start
+				if period >= ALTITUDE_MIN_PERIOD && period <= ALTITUDE_MAX_PERIOD {
+					orbits = append(orbits, models.OrbitAltitude{
+						StartTimestamp: int64(math.Round(start)),
+						EndTimestamp:   int64(math.Round(nodeT)),
+						MeanAltitudeKm: math.Round(area/period*1e5) / 1e5,
+						Samples:        samples,
+					})
+				}
+			}
+
+			inOrbit = true
+			start, lastT, lastAlt, area, samples = nodeT, nodeT, nodeAlt, 0, 0
+		}
+
+		if inOrbit {
+			t := float64(curr.Timestamp)
+			area += (t - lastT) * (lastAlt + curr.Altitude) / 2
+			lastT, lastAlt = t, curr.Altitude
+			samples++
+		}
+	}
+
+	return orbits
+}
+
+// detectReboosts finds revolutions across which the mean altitude steps up.
+// Each step is compared on medians of ALTITUDE_REBOOST_WINDOW orbits so one
+// noisy orbit can't fake it, and only the largest step of a run is kept.
+func detectReboosts(series []models.OrbitAltitude) []detectedReboost {
+	w := ALTITUDE_REBOOST_WINDOW
+	if len(series) < 2*w+1 {
+		return nil
+	}
+
+	steps := make([]float64, len(series))
+	for i := w; i < len(series)-w; i++ {
+		steps[i] = medianAltitude(series[i+1:i+1+w]) - medianAltitude(series[i-w:i])
+	}
+
+	var reboosts []detectedReboost
+	for i := w; i < len(series)-w; i++ {
+		if steps[i] >= ALTITUDE_REBOOST_MIN_KM && steps[i] > steps[i-1] && steps[i] >= steps[i+1] {
+			reboosts = append(reboosts, detectedReboost{orbit: series[i], stepKm: math.Round(steps[i]*100) / 100})
+		}
+	}
+	return reboosts
+}
+
+func medianAltitude(orbits []models.OrbitAltitude) float64 {
+	altitudes := make([]float64, len(orbits))
+	for i, orbit := range orbits {
+		altitudes[i] = orbit.MeanAltitudeKm
+	}
+	slices.Sort(altitudes)
+
+	mid := len(altitudes) / 2
+	if len(altitudes)%2 == 0 {
+		return (altitudes[mid-1] + altitudes[mid]) / 2
+	}
+	return altitudes[mid]
+}
+
+// reboostDeltaV estimates the velocity change in m/s that raised a circular
+// orbit at altitudeKm by stepKm: dv = v/(2a) * da.
+func reboostDeltaV(altitudeKm, stepKm float64) float64 {
+	a := EARTH_RADIUS_KM + altitudeKm
+	v := math.Sqrt(EARTH_MU / a)
+	return v / (2 * a) * stepKm * 1000
+}
+
+// fitDecay fits a line through the orbit means against their mid times.
+func fitDecay(orbits []models.OrbitAltitude) *decayFit {
+	n := len(orbits)
+	if n < 3 {
+		return nil
+	}
+
+	var sumX, sumY float64
+	for _, orbit := range orbits {
+		sumX += orbitMidTime(orbit)
+		sumY += orbit.MeanAltitudeKm
+	}
+	fit := &decayFit{n: n, meanX: sumX / float64(n)}
+	meanY := sumY / float64(n)
+
+	var sxy float64
+	for _, orbit := range orbits {
+		dx := orbitMidTime(orbit) - fit.meanX
+		fit.sxx += dx * dx
+		sxy += dx * (orbit.MeanAltitudeKm - meanY)
+	}
+	if fit.sxx == 0 {
+		return nil
+	}
+	fit.slope = sxy / fit.sxx
+	fit.intercept = meanY - fit.slope*fit.meanX
+
+	var sse float64
+	for _, orbit := range orbits {
+		r := orbit.MeanAltitudeKm - fit.at(orbitMidTime(orbit))
+		sse += r * r
+	}
+	fit.residual = math.Sqrt(sse / float64(n-2))
+
+	return fit
+}
+
+func orbitMidTime(orbit models.OrbitAltitude) float64 {
+	return float64(orbit.StartTimestamp+orbit.EndTimestamp) / 2
+}
+
+// GetTrend fits the decay rate over the last days, starting after the most
+// recent reboost on the mission timeline so the fit sees drag only.
+func (s *AltitudeService) GetTrend(days int) (*models.AltitudeTrendResponse, error) {
+	trend, _, err := s.trend(days)
+	return trend, err
+}
+
+func (s *AltitudeService) trend(days int) (*models.AltitudeTrendResponse, *decayFit, error) {
+	now := time.Now()
+	from := now.AddDate(0, 0, -days)
+
+	var series []models.OrbitAltitude
+	if err := s.db.Where("start_timestamp >= ?", from.Unix()).Order("start_timestamp asc").Find(&series).Error; err != nil {
+		return nil, nil, err
+	}
+
+	reboosts, err := s.missionEventService.GetEvents(MissionEventFilter{
+		Type:  models.MissionEventReboost,
+		From:  from.Unix(),
+		To:    now.Unix(),
+		Limit: 1000,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fitFrom := from.Unix()
+	if len(reboosts) > 0 {
+		last := reboosts[len(reboosts)-1]
+		fitFrom = last.StartAt.Unix()
+		if last.EndAt != nil {
+			fitFrom = last.EndAt.Unix()
+		}
+	}
+
+	var fitted []models.OrbitAltitude
+	for _, orbit := range series {
+		if orbit.StartTimestamp >= fitFrom {
+			fitted = append(fitted, orbit)
+		}
+	}
+	if len(fitted) < ALTITUDE_MIN_FIT_ORBITS {
+		return nil, nil, fmt.Errorf("%w: %d complete orbits since %s, need %d",
+			ErrInsufficientAltitudeData, len(fitted), time.Unix(fitFrom, 0).UTC().Format(time.RFC3339), ALTITUDE_MIN_FIT_ORBITS)
+	}
+
+	fit := fitDecay(fitted)
+	if fit == nil {
+		return nil, nil, fmt.Errorf("%w: orbits too close together to fit", ErrInsufficientAltitudeData)
+	}
+
+	return &models.AltitudeTrendResponse{
+		From:                 from.Unix(),
+		To:                   now.Unix(),
+		FitFrom:              fitFrom,
+		Orbits:               len(fitted),
+		CurrentAltitudeKm:    math.Round(fit.at(float64(now.Unix()))*1000) / 1000,
+		DecayMetersPerDay:    math.Round(fit.decayMetersPerDay()*10) / 10,
+		DecayStdErrPerDay:    math.Round(fit.decayStdErrPerDay()*10) / 10,
+		ResidualStdDevMeters: math.Round(fit.residual * 1000),
+		Reboosts:             reboosts,
+		Series:               series,
+	}, fit, nil
+}
+
+// GetForecast extrapolates the trend over the next days, one point a day,
+// with a 95% prediction interval that widens with distance from the data.
+// The line ignores the solar activity that drives drag and any future
+// reboost, so it is meant for the coming weeks only.
+func (s *AltitudeService) GetForecast(days, trendDays int) (*models.AltitudeForecastResponse, error) {
+	trend, fit, err := s.trend(trendDays)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]models.AltitudeForecastPoint, days+1)
+	for day := range points {
+		t := trend.To + int64(day)*86400
+		altitude := fit.at(float64(t))
+		interval := fit.predictionInterval(float64(t))
+		points[day] = models.AltitudeForecastPoint{
+			Timestamp:  t,
+			AltitudeKm: math.Round(altitude*1000) / 1000,
+			LowerKm:    math.Round((altitude-interval)*1000) / 1000,
+			UpperKm:    math.Round((altitude+interval)*1000) / 1000,
+		}
+	}
+
+	return &models.AltitudeForecastResponse{
+		FitFrom:           trend.FitFrom,
+		Orbits:            trend.Orbits,
+		DecayMetersPerDay: trend.DecayMetersPerDay,
+		DecayStdErrPerDay: trend.DecayStdErrPerDay,
+		Days:              days,
+		Points:            points,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"iss-model-backend/internal/models"
+)
+
+// syntheticPositions samples a circular orbit every 10 s for the given number
+// of seconds. The altitude decays linearly, swings with the argument of
+// latitude like the real series does and jumps by reboostKm at reboostAt.
+func syntheticPositions(seconds int64, decayKmPerDay, reboostKm float64, reboostAt int64) []*models.ISSPosition {
+	const start, period = int64(1740000000), 5550.0
+
+	var positions []*models.ISSPosition
+	for t := int64(0); t <= seconds; t += 10 {
+		u := 2 * math.Pi * float64(t) / period
+		altitude := 420 - decayKmPerDay*float64(t)/86400 + 8*math.Sin(2*u)
+		if t >= reboostAt {
+			altitude += reboostKm
+		}
+		positions = append(positions, &models.ISSPosition{
+			Latitude:  51.6 * math.Sin(u+0.3),
+			Altitude:  altitude,
+			Timestamp: start + t,
+		})
+	}
+	return positions
+}
+
+func TestOrbitAltitudes(t *testing.T) {
+	positions := syntheticPositions(86400, 0.1, 0, math.MaxInt64)
+
+	// A collector outage drops the revolution it falls in.
+	holed := append(append([]*models.ISSPosition{}, positions[:3000]...), positions[3010:]...)
+
+	orbits := orbitAltitudes(positions)
+	if len(orbits) != 14 {
+		t.Fatalf("got %d orbits, want 14", len(orbits))
+	}
+	if got := len(orbitAltitudes(holed)); got != 13 {
+		t.Errorf("got %d orbits with a gap, want 13", got)
+	}
+
+	for _, orbit := range orbits {
+		elapsed := orbitMidTime(orbit) - 1740000000
+		want := 420 - 0.1*elapsed/86400
+		if math.Abs(orbit.MeanAltitudeKm-want) > 0.005 {
+			t.Errorf("orbit at %d: mean %f, want %f", orbit.StartTimestamp, orbit.MeanAltitudeKm, want)
+		}
+		if period := orbit.EndTimestamp - orbit.StartTimestamp; period < 5549 || period > 5551 {
+			t.Errorf("orbit at %d lasts %d s", orbit.StartTimestamp, period)
+		}
+	}
+
+	fit := fitDecay(orbits)
+	if math.Abs(fit.decayMetersPerDay()-100) > 1 {
+		t.Errorf("decay %f m/day, want 100", fit.decayMetersPerDay())
+	}
+}
+
+func TestDetectReboosts(t *testing.T) {
+	orbits := orbitAltitudes(syntheticPositions(86400, 0.1, 1.5, 40000))
+
+	reboosts := detectReboosts(orbits)
+	if len(reboosts) != 1 {
+		t.Fatalf("got %d reboosts, want 1", len(reboosts))
+	}
+	if reboosts[0].orbit.StartTimestamp > 1740040000 || reboosts[0].orbit.EndTimestamp < 1740040000 {
+		t.Errorf("reboost placed in orbit %d-%d, want the one containing 1740040000",
+			reboosts[0].orbit.StartTimestamp, reboosts[0].orbit.EndTimestamp)
+	}
+	if math.Abs(reboosts[0].stepKm-1.5) > 0.05 {
+		t.Errorf("step %f km, want about 1.5", reboosts[0].stepKm)
+	}
+
+	if got := detectReboosts(orbitAltitudes(syntheticPositions(86400, 0.1, 0, math.MaxInt64))); len(got) != 0 {
+		t.Errorf("decay alone detected as %d reboosts", len(got))
+	}
+
+	// A 1 km raise of the ISS orbit takes about 0.56 m/s.
+	if dv := reboostDeltaV(420, 1); math.Abs(dv-0.565) > 0.01 {
+		t.Errorf("delta-v %f m/s, want about 0.565", dv)
+	}
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	FILL_SUBSAMPLES = 4 // scanlines per pixel row when filling polygons
+	GRATICULE_DEG   = 30
+	NIGHT_OPACITY   = 0.55
+)
+
+var (
+	oceanColor     = color.NRGBA{0x0c, 0x24, 0x3f, 0xff}
+	landColor      = color.NRGBA{0x3d, 0x5a, 0x45, 0xff}
+	graticuleColor = color.NRGBA{0xff, 0xff, 0xff, 0x22}
+	nightColor     = color.NRGBA{0x02, 0x05, 0x12, 0xff}
+)
+
+// landJSON outlines the continents and larger islands as a GeoJSON
+// FeatureCollection of polygons. Coasts are simplified to roughly half a
+// degree, which is plenty for a world map a few thousand pixels wide. The
+// Black and Caspian Seas are holes in Eurasia.
+//
+//go:embed data/land.json
+var landJSON []byte
+
+var land = mustLoadLand()
+
+func mustLoadLand() [][]models.Coordinates {
+	var collection struct {
+		Features []struct {
+			Geometry struct {
+				Coordinates []models.Coordinates `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(landJSON, &collection); err != nil || len(collection.Features) == 0 {
+		panic(fmt.Sprintf("invalid embedded land outlines: %v", err))
+	}
+
+	result := make([][]models.Coordinates, len(collection.Features))
+	for i, feature := range collection.Features {
+		result[i] = feature.Geometry.Coordinates
+	}
+	return result
+}
+
+// mapCanvas is an equirectangular (plate carrée) world map: longitude -180
+// to 180 from left to right and latitude 90 to -90 from top to bottom.
+type mapCanvas struct {
+	img           *image.NRGBA
+	width, height int
+}
+
+func newMapCanvas(width, height int) *mapCanvas {
+	return &mapCanvas{
+		img:    image.NewNRGBA(image.Rect(0, 0, width, height)),
+		width:  width,
+		height: height,
+	}
+}
+
+// project returns the pixel position of a point. Longitudes outside
+// [-180, 180] land beyond the edges, which lets paths wrap.
+func (c *mapCanvas) project(lat, lon float64) (x, y float64) {
+	return (lon + 180) / 360 * float64(c.width), (90 - lat) / 180 * float64(c.height)
+}
+
+// blend paints col over a pixel, scaled by opacity in [0, 1].
+func (c *mapCanvas) blend(x, y int, col color.NRGBA, opacity float64) {
+	if x < 0 || y < 0 || x >= c.width || y >= c.height {
+		return
+	}
+	a := math.Min(1, opacity) * float64(col.A) / 255
+	if a <= 0 {
+		return
+	}
+
+	pix := c.img.Pix[c.img.PixOffset(x, y):]
+	dstA := float64(pix[3]) / 255
+	outA := a + dstA*(1-a)
+	for i, v := range [3]uint8{col.R, col.G, col.B} {
+		pix[i] = uint8(math.Round((float64(v)*a + float64(pix[i])*dstA*(1-a)) / outA))
+	}
+	pix[3] = uint8(math.Round(outA * 255))
+}
+
+func (c *mapCanvas) drawBaseMap() {
+	draw.Draw(c.img, c.img.Bounds(), image.NewUniform(oceanColor), image.Point{}, draw.Src)
+
+	for _, rings := range land {
+		c.fillRings(rings, landColor)
+	}
+
+	graticule := c.newStroke()
+	for lon := -180.0; lon <= 180; lon += GRATICULE_DEG {
+		c.strokePath(graticule, models.Coordinates{{lon, -90}, {lon, 90}}, 1)
+	}
+	for lat := -90.0 + GRATICULE_DEG; lat < 90; lat += GRATICULE_DEG {
+		c.strokePath(graticule, models.Coordinates{{-180, lat}, {180, lat}}, 1)
+	}
+	c.paintStroke(graticule, graticuleColor)
+}
+
+// fillRings fills a polygon given as closed rings with the even-odd rule,
+// so later rings cut holes. Each pixel row is sampled FILL_SUBSAMPLES times
+// and partially covered pixels are blended, which smooths the coasts.
+func (c *mapCanvas) fillRings(rings []models.Coordinates, col color.NRGBA) {
+	minLat, maxLat := 90.0, -90.0
+	for _, ring := range rings {
+		for _, p := range ring {
+			minLat, maxLat = math.Min(minLat, p[1]), math.Max(maxLat, p[1])
+		}
+	}
+	_, top := c.project(maxLat, 0)
+	_, bottom := c.project(minLat, 0)
+
+	coverage := make([]float64, c.width)
+	var crossings []float64
+	for row := max(0, int(top)); row < min(c.height, int(math.Ceil(bottom))); row++ {
+		clear(coverage)
+
+		for sample := range FILL_SUBSAMPLES {
+			y := float64(row) + (float64(sample)+0.5)/FILL_SUBSAMPLES
+			lat := 90 - y/float64(c.height)*180
+
+			crossings = crossings[:0]
+			for _, ring := range rings {
+				for i := 0; i+1 < len(ring); i++ {
+					p, q := ring[i], ring[i+1]
+					if (p[1] > lat) != (q[1] > lat) {
+						x, _ := c.project(lat, p[0]+(lat-p[1])/(q[1]-p[1])*(q[0]-p[0]))
+						crossings = append(crossings, x)
+					}
+				}
+			}
+			sort.Float64s(crossings)
+
+			for i := 0; i+1 < len(crossings); i += 2 {
+				addSpan(coverage, crossings[i], crossings[i+1], 1.0/FILL_SUBSAMPLES)
+			}
+		}
+
+		for x, covered := range coverage {
+			if covered > 0 {
+				c.blend(x, row, col, covered)
+			}
+		}
+	}
+}
+
+// addSpan adds weight to the pixels between x0 and x1, in proportion to how
+// much of each pixel the span covers.
+func addSpan(coverage []float64, x0, x1, weight float64) {
+	x0, x1 = math.Max(0, x0), math.Min(float64(len(coverage)), x1)
+	if x1 <= x0 {
+		return
+	}
+
+	first, last := int(x0), int(x1)
+	if first == last {
+		coverage[first] += (x1 - x0) * weight
+		return
+	}
+
+	coverage[first] += (float64(first+1) - x0) * weight
+	for i := first + 1; i < last; i++ {
+		coverage[i] += weight
+	}
+	if last < len(coverage) {
+		coverage[last] += (x1 - float64(last)) * weight
+	}
+}
+
+// newStroke returns a coverage mask for strokePath. Collecting a path in a
+// mask before painting it keeps overlapping segment ends from darkening.
+func (c *mapCanvas) newStroke() []float64 {
+	return make([]float64, c.width*c.height)
+}
+
+// strokePath adds an antialiased line of the given pixel width along points
+// to mask. Longitudes must be continuous (see unwrapLongitudes); the path is
+// also drawn one world width to either side so it wraps at the edges.
+func (c *mapCanvas) strokePath(mask []float64, points models.Coordinates, width float64) {
+	for _, shift := range []float64{-360, 0, 360} {
+		for i := 0; i+1 < len(points); i++ {
+			x0, y0 := c.project(points[i][1], points[i][0]+shift)
+			x1, y1 := c.project(points[i+1][1], points[i+1][0]+shift)
+			c.strokeSegment(mask, x0, y0, x1, y1, width/2)
+		}
+	}
+}
+
+func (c *mapCanvas) strokeSegment(mask []float64, x0, y0, x1, y1, halfWidth float64) {
+	reach := halfWidth + 1
+	minX, maxX := max(0, int(math.Min(x0, x1)-reach)), min(c.width-1, int(math.Max(x0, x1)+reach))
+	minY, maxY := max(0, int(math.Min(y0, y1)-reach)), min(c.height-1, int(math.Max(y0, y1)+reach))
+
+	dx, dy := x1-x0, y1-y0
+	lengthSq := dx*dx + dy*dy
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+
+			t := 0.0
+			if lengthSq > 0 {
+				t = math.Max(0, math.Min(1, ((px-x0)*dx+(py-y0)*dy)/lengthSq))
+			}
+			distance := math.Hypot(px-(x0+t*dx), py-(y0+t*dy))
+
+			if covered := halfWidth + 0.5 - distance; covered > 0 {
+				i := y*c.width + x
+				mask[i] = math.Max(mask[i], math.Min(1, covered))
+			}
+		}
+	}
+}
+
+func (c *mapCanvas) paintStroke(mask []float64, col color.NRGBA) {
+	for i, covered := range mask {
+		if covered > 0 {
+			c.blend(i%c.width, i/c.width, col, covered)
+		}
+	}
+}
+
+// fillDisc paints an antialiased disc of radius r pixels.
+func (c *mapCanvas) fillDisc(cx, cy, r float64, col color.NRGBA) {
+	for y := max(0, int(cy-r-1)); y <= min(c.height-1, int(cy+r+1)); y++ {
+		for x := max(0, int(cx-r-1)); x <= min(c.width-1, int(cx+r+1)); x++ {
+			distance := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			if covered := r + 0.5 - distance; covered > 0 {
+				c.blend(x, y, col, covered)
+			}
+		}
+	}
+}
+
+func (c *mapCanvas) fillRect(x0, y0, x1, y1 float64, col color.NRGBA) {
+	for y := max(0, int(math.Round(y0))); y < min(c.height, int(math.Round(y1))); y++ {
+		for x := max(0, int(math.Round(x0))); x < min(c.width, int(math.Round(x1))); x++ {
+			c.blend(x, y, col, 1)
+		}
+	}
+}
+
+// forEachPixel calls fn with the direction of every pixel's center as a
+// unit vector from the Earth's center.
+func (c *mapCanvas) forEachPixel(fn func(x, y int, direction vec3)) {
+	cosLon := make([]float64, c.width)
+	sinLon := make([]float64, c.width)
+	for x := range c.width {
+		lon := toRadians((float64(x)+0.5)/float64(c.width)*360 - 180)
+		cosLon[x], sinLon[x] = math.Cos(lon), math.Sin(lon)
+	}
+
+	for y := range c.height {
+		lat := toRadians(90 - (float64(y)+0.5)/float64(c.height)*180)
+		cosLat, sinLat := math.Cos(lat), math.Sin(lat)
+		for x := range c.width {
+			fn(x, y, vec3{cosLat * cosLon[x], cosLat * sinLon[x], sinLat})
+		}
+	}
+}
+
+// shadeNight darkens the map where the Sun is below the horizon, fading in
+// from the terminator to full darkness at the end of nautical twilight.
+func (c *mapCanvas) shadeNight(sunLat, sunLon float64) {
+	sun := subpointDirection(sunLat, sunLon)
+	c.forEachPixel(func(x, y int, direction vec3) {
+		elevation := toDegrees(math.Asin(math.Max(-1, math.Min(1, direction.dot(sun)))))
+		darkness := (SUN_ELEVATION_TERMINATOR - elevation) / (SUN_ELEVATION_TERMINATOR - SUN_ELEVATION_NAUTICAL)
+		if darkness > 0 {
+			c.blend(x, y, nightColor, math.Min(1, darkness)*NIGHT_OPACITY)
+		}
+	})
+}
+
+// fillCap shades the spherical cap within radius degrees of a point. Its
+// edge is left to a stroke of capRing, which stays sharp where the
+// projection stretches the cap.
+func (c *mapCanvas) fillCap(lat, lon, radius float64, col color.NRGBA) {
+	center := subpointDirection(lat, lon)
+	minDot := math.Cos(toRadians(radius))
+	c.forEachPixel(func(x, y int, direction vec3) {
+		if direction.dot(center) >= minDot {
+			c.blend(x, y, col, 1)
+		}
+	})
+}
+
+// unwrapLongitudes shifts longitudes by whole turns so that consecutive
+// points never jump across the antimeridian.
+func unwrapLongitudes(points models.Coordinates) models.Coordinates {
+	result := make(models.Coordinates, len(points))
+	for i, p := range points {
+		if i > 0 {
+			prev := result[i-1][0]
+			p[0] = prev + normalizeDegrees(p[0]-prev, 180)
+		}
+		result[i] = p
+	}
+	return result
+}
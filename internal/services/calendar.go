@@ -0,0 +1,371 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	CALENDAR_DEFAULT_HOURS = PASS_MAX_HOURS
+	// CALENDAR_PAST_DAYS keeps recent dockings in the feed so they do not
+	// vanish from calendars the moment they happen.
+	CALENDAR_PAST_DAYS = 30
+	// CALENDAR_PASS_MATCH_SECONDS is how far a predicted TCA may be from an
+	// issued pass to keep its UID. Seeding the propagator at different times
+	// moves a prediction by seconds to a few minutes, while consecutive
+	// passes over one place are at least an orbit apart.
+	CALENDAR_PASS_MATCH_SECONDS = 10 * 60
+	// CALENDAR_PASS_KEEP is how long after its TCA an issued pass is kept.
+	CALENDAR_PASS_KEEP   = 24 * time.Hour
+	CALENDAR_REFRESH     = "PT6H"
+	CALENDAR_UID_DOMAIN  = "iss-model-backend"
+	CALENDAR_LINE_OCTETS = 75
+)
+
+// calendarEvent is one VEVENT. Sequence is only set for events backed by a
+// stored row, whose UpdatedAt orders revisions.
+type calendarEvent struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	URL         string
+	Modified    time.Time
+	Sequence    int64
+}
+
+// missionEventLabels prefix event titles in calendar summaries.
+var missionEventLabels = map[string]string{
+	models.MissionEventEVA:        "Spacewalk",
+	models.MissionEventDocking:    "Docking",
+	models.MissionEventUndocking:  "Undocking",
+	models.MissionEventReboost:    "Reboost",
+	models.MissionEventLaunch:     "Launch",
+	models.MissionEventExperiment: "Experiment",
+}
+
+// CalendarService builds iCalendar feeds out of pass predictions, the
+// visiting vehicle schedule and the mission timeline.
+type CalendarService struct {
+	db                  *gorm.DB
+	issService          *ISSService
+	vehicleService      *VehicleService
+	missionEventService *MissionEventService
+}
+
+func NewCalendarService(db *gorm.DB, issService *ISSService, vehicleService *VehicleService, missionEventService *MissionEventService) *CalendarService {
+	return &CalendarService{
+		db:                  db,
+		issService:          issService,
+		vehicleService:      vehicleService,
+		missionEventService: missionEventService,
+	}
+}
+
+// GetFeed returns an iCalendar (RFC 5545) document with the visible passes
+// over the observer in the next hours, plus vehicle dockings and undockings
+// and mission events from the last CALENDAR_PAST_DAYS onwards. baseURL is prepended to links
+// back to the API, such as the sky plot of each pass.
+func (s *CalendarService) GetFeed(observer models.Observer, hours int, minElevation float64, baseURL string) (string, error) {
+	result, err := s.issService.GetUpcomingPasses(observer, hours, minElevation)
+	if err != nil {
+		return "", err
+	}
+
+	var visible []models.ObserverPass
+	for _, pass := range result.Passes {
+		if pass.Visible {
+			visible = append(visible, pass)
+		}
+	}
+
+	uids, err := s.passUIDs(observer, visible)
+	if err != nil {
+		return "", err
+	}
+
+	var events []calendarEvent
+	for i, pass := range visible {
+		events = append(events, passEvent(uids[i], pass, baseURL))
+	}
+
+	vehicles, err := s.vehicleService.GetAllVehicles(0)
+	if err != nil {
+		return "", err
+	}
+
+	since := time.Now().AddDate(0, 0, -CALENDAR_PAST_DAYS)
+	for _, vehicle := range vehicles {
+		events = append(events, vehicleEvents(vehicle, since)...)
+	}
+
+	// Page through the timeline rather than stopping at one page.
+	filter := MissionEventFilter{From: since.Unix(), Limit: MISSION_EVENT_MAX_LIMIT}
+	for {
+		missionEvents, err := s.missionEventService.GetEvents(filter)
+		if err != nil {
+			return "", err
+		}
+		for _, missionEvent := range missionEvents {
+			if event, ok := missionCalendarEvent(missionEvent); ok {
+				events = append(events, event)
+			}
+		}
+		if len(missionEvents) < filter.Limit {
+			break
+		}
+		filter.Offset += len(missionEvents)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Start.Before(events[j].Start)
+	})
+
+	return writeICS(events, time.Now()), nil
+}
+
+// passUIDs returns a UID for each pass, in order. A pass reuses the UID of
+// the pass issued earlier over the same observer with the nearest TCA
+// within CALENDAR_PASS_MATCH_SECONDS, which then follows the new TCA so the
+// prediction may keep drifting; other passes get a new UID from their TCA.
+func (s *CalendarService) passUIDs(observer models.Observer, passes []models.ObserverPass) ([]string, error) {
+	uids := make([]string, len(passes))
+	if len(passes) == 0 {
+		return uids, nil
+	}
+	key := fmt.Sprintf("%.2f_%.2f", observer.Latitude, observer.Longitude)
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		cutoff := time.Now().Add(-CALENDAR_PASS_KEEP).Unix()
+		if err := tx.Where("tca < ?", cutoff).Delete(&models.CalendarPass{}).Error; err != nil {
+			return err
+		}
+
+		var issued []models.CalendarPass
+		err := tx.Where("observer = ? AND tca BETWEEN ? AND ?", key,
+			passes[0].TCA.Timestamp-CALENDAR_PASS_MATCH_SECONDS,
+			passes[len(passes)-1].TCA.Timestamp+CALENDAR_PASS_MATCH_SECONDS).
+			Find(&issued).Error
+		if err != nil {
+			return err
+		}
+
+		for i, pass := range passes {
+			if j := matchIssuedPass(issued, pass.TCA.Timestamp); j >= 0 {
+				uids[i] = issued[j].UID
+				if issued[j].TCA != pass.TCA.Timestamp {
+					if err := tx.Model(&issued[j]).Update("tca", pass.TCA.Timestamp).Error; err != nil {
+						return err
+					}
+					issued[j].TCA = pass.TCA.Timestamp
+				}
+				continue
+			}
+
+			row := models.CalendarPass{
+				Observer: key,
+				TCA:      pass.TCA.Timestamp,
+				UID: fmt.Sprintf("pass-%s-%s@%s",
+					time.Unix(pass.TCA.Timestamp, 0).UTC().Format("20060102T150405Z"), key, CALENDAR_UID_DOMAIN),
+			}
+			// A concurrent request may have issued the same UID already.
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+				return err
+			}
+			issued = append(issued, row)
+			uids[i] = row.UID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match calendar passes: %w", err)
+	}
+	return uids, nil
+}
+
+// matchIssuedPass returns the index of the issued pass with the TCA nearest
+// tca within CALENDAR_PASS_MATCH_SECONDS, or -1.
+func matchIssuedPass(issued []models.CalendarPass, tca int64) int {
+	best, bestDiff := -1, int64(CALENDAR_PASS_MATCH_SECONDS)
+	for i, pass := range issued {
+		diff := pass.TCA - tca
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= bestDiff {
+			best, bestDiff = i, diff
+		}
+	}
+	return best
+}
+
+func passEvent(uid string, pass models.ObserverPass, baseURL string) calendarEvent {
+	description := fmt.Sprintf("Rises: %s UTC in the %s (%.0f°)\nHighest: %s UTC, %.0f° in the %s\nSets: %s UTC in the %s (%.0f°)\nDuration: %d min %02d s",
+		formatUTCTime(pass.AOS.Timestamp), compassPoint(pass.AOS.Azimuth), pass.AOS.Azimuth,
+		formatUTCTime(pass.TCA.Timestamp), pass.TCA.Elevation, compassPoint(pass.TCA.Azimuth),
+		formatUTCTime(pass.LOS.Timestamp), compassPoint(pass.LOS.Azimuth), pass.LOS.Azimuth,
+		pass.DurationSeconds/60, pass.DurationSeconds%60)
+
+	url := ""
+	if pass.SkyPlotURL != "" {
+		url = baseURL + pass.SkyPlotURL
+		description += "\nSky plot: " + url
+	}
+
+	return calendarEvent{
+		UID:   uid,
+		Start: time.Unix(pass.AOS.Timestamp, 0),
+		End:   time.Unix(pass.LOS.Timestamp, 0),
+		Summary: fmt.Sprintf("ISS pass, max %.0f° (%s → %s)",
+			pass.TCA.Elevation, compassPoint(pass.AOS.Azimuth), compassPoint(pass.LOS.Azimuth)),
+		Description: description,
+		URL:         url,
+	}
+}
+
+// vehicleEvents turns a visiting vehicle into its docking and, once the
+// departure is scheduled, undocking events. Events before since are left out.
+func vehicleEvents(vehicle models.VisitingVehicle, since time.Time) []calendarEvent {
+	location := vehicle.Port
+	if vehicle.Module != nil {
+		location = fmt.Sprintf("%s %s", vehicle.Module.Name, vehicle.Port)
+	}
+
+	var events []calendarEvent
+	add := func(kind, verb, preposition string, at time.Time) {
+		if at.Before(since) {
+			return
+		}
+		events = append(events, calendarEvent{
+			UID:         fmt.Sprintf("vehicle-%d-%s@%s", vehicle.ID, kind, CALENDAR_UID_DOMAIN),
+			Start:       at,
+			Summary:     fmt.Sprintf("%s: %s %s %s", verb, vehicle.Name, preposition, location),
+			Description: vehicle.Description,
+			Modified:    vehicle.UpdatedAt,
+			Sequence:    vehicle.UpdatedAt.Unix(),
+		})
+	}
+
+	add("docking", "Docking", "at", vehicle.ArrivalAt)
+	if vehicle.DepartureAt != nil {
+		add("undocking", "Undocking", "from", *vehicle.DepartureAt)
+	}
+	return events
+}
+
+// missionCalendarEvent turns a mission timeline entry into a VEVENT. Dockings
+// and undockings of a listed vehicle are left to vehicleEvents so they don't
+// show up twice.
+func missionCalendarEvent(event models.MissionEvent) (calendarEvent, bool) {
+	if (event.Type == models.MissionEventDocking || event.Type == models.MissionEventUndocking) && len(event.Vehicles) > 0 {
+		return calendarEvent{}, false
+	}
+
+	calEvent := calendarEvent{
+		UID:         fmt.Sprintf("event-%d@%s", event.ID, CALENDAR_UID_DOMAIN),
+		Start:       event.StartAt,
+		Summary:     fmt.Sprintf("%s: %s", missionEventLabels[event.Type], event.Title),
+		Description: event.Description,
+		Modified:    event.UpdatedAt,
+		Sequence:    event.UpdatedAt.Unix(),
+	}
+	if event.EndAt != nil {
+		calEvent.End = *event.EndAt
+	}
+	if len(event.Crew) > 0 {
+		if calEvent.Description != "" {
+			calEvent.Description += "\n\n"
+		}
+		calEvent.Description += "Crew: " + strings.Join(event.Crew, ", ")
+	}
+	return calEvent, true
+}
+
+// writeICS serializes events as a VCALENDAR with CRLF line endings and lines
+// folded at CALENDAR_LINE_OCTETS.
+func writeICS(events []calendarEvent, stamp time.Time) string {
+	var b strings.Builder
+	line := func(name, value string) {
+		b.WriteString(foldICSLine(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//"+CALENDAR_UID_DOMAIN+"//ISS events//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	line("X-WR-CALNAME", "ISS passes and events")
+	line("REFRESH-INTERVAL;VALUE=DURATION", CALENDAR_REFRESH)
+	line("X-PUBLISHED-TTL", CALENDAR_REFRESH)
+
+	for _, event := range events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		line("DTSTAMP", formatICSTime(stamp))
+		line("DTSTART", formatICSTime(event.Start))
+		// Without DTEND an event lasts zero seconds, which suits dockings.
+		if !event.End.IsZero() {
+			line("DTEND", formatICSTime(event.End))
+		}
+		line("SUMMARY", escapeICSText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escapeICSText(event.Description))
+		}
+		if event.URL != "" {
+			line("URL", event.URL)
+		}
+		if !event.Modified.IsZero() {
+			line("LAST-MODIFIED", formatICSTime(event.Modified))
+			line("SEQUENCE", fmt.Sprint(event.Sequence))
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return b.String()
+}
+
+func formatICSTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+func formatUTCTime(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format("15:04:05")
+}
+
+func escapeICSText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// foldICSLine splits a content line into CALENDAR_LINE_OCTETS chunks joined
+// by CRLF and a space, without breaking UTF-8 sequences.
+func foldICSLine(line string) string {
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > CALENDAR_LINE_OCTETS {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+// compassPoint names a bearing on the 16-point compass rose.
+func compassPoint(azimuth float64) string {
+	points := []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+	return points[int(math.Round(normalizeDegrees(azimuth, 360)/22.5))%16]
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestWriteICS(t *testing.T) {
+	stamp := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []calendarEvent{{
+		UID:         "vehicle-7-docking@iss-model-backend",
+		Start:       time.Date(2025, 3, 2, 8, 30, 0, 0, time.UTC),
+		Summary:     "Docking: Crew-10, Harmony; zenith",
+		Description: strings.Repeat("Long description é ", 10) + "\nsecond line",
+		Modified:    stamp,
+		Sequence:    stamp.Unix(),
+	}}
+
+	ics := writeICS(events, stamp)
+
+	if !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Fatalf("feed does not end with a CRLF-terminated END:VCALENDAR")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > CALENDAR_LINE_OCTETS {
+			t.Errorf("line of %d octets not folded: %q", len(line), line)
+		}
+		if strings.Contains(line, "\n") {
+			t.Errorf("bare LF in line %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, want := range []string{
+		"UID:vehicle-7-docking@iss-model-backend\r\n",
+		"DTSTART:20250302T083000Z\r\n",
+		`SUMMARY:Docking: Crew-10\, Harmony\; zenith` + "\r\n",
+		`é \nsecond line` + "\r\n",
+		"SEQUENCE:1740830400\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("feed is missing %q", want)
+		}
+	}
+	if strings.Contains(unfolded, "DTEND") {
+		t.Errorf("event without an end got a DTEND")
+	}
+}
+
+func TestPassEvent(t *testing.T) {
+	pass := models.ObserverPass{
+		AOS:        models.SkyPoint{Timestamp: 1740855000, Azimuth: 250},
+		TCA:        models.SkyPoint{Timestamp: 1740855300, Azimuth: 180, Elevation: 40},
+		LOS:        models.SkyPoint{Timestamp: 1740855600, Azimuth: 100},
+		SkyPlotURL: "/iss/passes/1740855000/sky.svg",
+	}
+
+	event := passEvent("pass-20250301T185500Z-50.06_19.94@iss-model-backend", pass, "https://example.org")
+	if event.UID != "pass-20250301T185500Z-50.06_19.94@iss-model-backend" {
+		t.Errorf("UID = %q", event.UID)
+	}
+	if event.Summary != "ISS pass, max 40° (WSW → E)" {
+		t.Errorf("Summary = %q", event.Summary)
+	}
+	if event.URL != "https://example.org/iss/passes/1740855000/sky.svg" {
+		t.Errorf("URL = %q", event.URL)
+	}
+}
+
+func TestMatchIssuedPass(t *testing.T) {
+	// Two passes an orbit apart; the first TCA sits just before 19:00 UTC.
+	issued := []models.CalendarPass{
+		{UID: "a", TCA: 1740855590},
+		{UID: "b", TCA: 1740855590 + 5580},
+	}
+
+	tests := []struct {
+		tca  int64
+		want int
+	}{
+		{1740855590, 0},
+		{1740855590 + 25, 0},   // drifted across 19:00 UTC
+		{1740855590 - 240, 0},  // minutes early after a refresh days ahead
+		{1740855590 + 5500, 1}, // the next orbit
+		{1740855590 + 2790, -1},
+	}
+
+	for _, tt := range tests {
+		if got := matchIssuedPass(issued, tt.tca); got != tt.want {
+			t.Errorf("matchIssuedPass(%d) = %d, want %d", tt.tca, got, tt.want)
+		}
+	}
+}
+
+func TestMissionCalendarEvent(t *testing.T) {
+	start := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(6 * time.Hour)
+	eva := models.MissionEvent{
+		ID:      3,
+		Type:    models.MissionEventEVA,
+		Title:   "US EVA 92",
+		StartAt: start,
+		EndAt:   &end,
+		Crew:    models.StringArray{"Anne McClain", "Nichole Ayers"},
+	}
+
+	event, ok := missionCalendarEvent(eva)
+	if !ok {
+		t.Fatalf("EVA left out of the calendar")
+	}
+	if event.UID != "event-3@iss-model-backend" || event.Summary != "Spacewalk: US EVA 92" || !event.End.Equal(end) {
+		t.Errorf("event = %+v", event)
+	}
+	if event.Description != "Crew: Anne McClain, Nichole Ayers" {
+		t.Errorf("Description = %q", event.Description)
+	}
+
+	docking := models.MissionEvent{
+		Type:     models.MissionEventDocking,
+		Title:    "Crew-10 docks",
+		StartAt:  start,
+		Vehicles: []models.VisitingVehicle{{ID: 1}},
+	}
+	if _, ok := missionCalendarEvent(docking); ok {
+		t.Errorf("docking of a listed vehicle would appear twice")
+	}
+}
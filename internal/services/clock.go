@@ -0,0 +1,56 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	SIM_MAX_SPEED    = 3600.0
+	SIM_MATCH_WINDOW = 5 // seconds; a stored row this close stands in for simulated time
+)
+
+var ErrInvalidSimulation = errors.New("invalid simulation")
+
+// Clock is the source of "now" for services that can run on simulated time.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// RealClock reads the system clock.
+var RealClock Clock = realClock{}
+
+// SimClock starts at a chosen instant and runs speed times faster than the
+// wall clock from the moment it is created.
+type SimClock struct {
+	start  time.Time
+	speed  float64
+	origin time.Time
+}
+
+func NewSimClock(start time.Time, speed float64) (*SimClock, error) {
+	if speed <= 0 || speed > SIM_MAX_SPEED {
+		return nil, fmt.Errorf("%w: speed must be between 0 and %.0f", ErrInvalidSimulation, SIM_MAX_SPEED)
+	}
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	return &SimClock{start: start, speed: speed, origin: time.Now()}, nil
+}
+
+func (c *SimClock) Now() time.Time {
+	elapsed := time.Since(c.origin)
+	return c.start.Add(time.Duration(float64(elapsed) * c.speed))
+}
+
+func (c *SimClock) Speed() float64 {
+	return c.speed
+}
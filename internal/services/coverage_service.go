@@ -0,0 +1,309 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	COVERAGE_CELL_DEG         = 1.0
+	COVERAGE_LAT_CELLS        = int(180 / COVERAGE_CELL_DEG)
+	COVERAGE_LON_CELLS        = int(360 / COVERAGE_CELL_DEG)
+	COVERAGE_SAMPLE_DEG       = 0.5 // track segments are sampled at least this finely
+	COVERAGE_DEFAULT_CELL_DEG = 5
+	COVERAGE_DEFAULT_DAYS     = 30
+	COVERAGE_MAX_DAYS         = 366
+	COVERAGE_CACHE_TTL        = time.Minute
+)
+
+var ErrInvalidCoverageQuery = errors.New("invalid coverage query")
+
+// coverageGrid holds seconds per cell, indexed [lat][lon].
+type coverageGrid struct {
+	seconds [][]float64
+	max     float64
+}
+
+func (g *coverageGrid) at(lat, lon float64) float64 {
+	latIdx, lonIdx := coverageCell(lat, lon)
+	return g.seconds[latIdx][lonIdx]
+}
+
+type CoverageService struct {
+	db *gorm.DB
+
+	// accumulateMu serializes the collector hook with the startup backfill.
+	// accumulated is the timestamp of the last position in the grid; neither
+	// adds a segment ending at or before it.
+	accumulateMu sync.Mutex
+	accumulated  int64
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedCoverageGrid
+}
+
+type cachedCoverageGrid struct {
+	grid    *coverageGrid
+	expires time.Time
+}
+
+func NewCoverageService(db *gorm.DB) *CoverageService {
+	service := &CoverageService{db: db, cache: make(map[string]cachedCoverageGrid)}
+
+	// Hold the hook back until the backfill has loaded the progress mark.
+	service.accumulateMu.Lock()
+	go service.backfill()
+
+	return service
+}
+
+func coverageCell(lat, lon float64) (latIdx, lonIdx int) {
+	latIdx = int(math.Floor((lat + 90) / COVERAGE_CELL_DEG))
+	latIdx = max(0, min(COVERAGE_LAT_CELLS-1, latIdx))
+
+	lonIdx = int(math.Floor((normalizeDegrees(lon, 180) + 180) / COVERAGE_CELL_DEG))
+	return latIdx, lonIdx % COVERAGE_LON_CELLS
+}
+
+// backfill adds the positions still in the raw position table that the grid
+// has not seen yet, so coverage starts filling on first deployment and
+// picks up where it left off after a restart. It releases accumulateMu,
+// which NewCoverageService takes.
+func (s *CoverageService) backfill() {
+	defer s.accumulateMu.Unlock()
+
+	var progress models.CoverageProgress
+	if err := s.db.FirstOrCreate(&progress, models.CoverageProgress{ID: 1}).Error; err != nil {
+		log.Printf("Failed to load coverage progress: %v", err)
+		return
+	}
+	s.accumulated = progress.Timestamp
+
+	var positions []*models.ISSPosition
+	err := s.db.Where("satellite_id = ? AND timestamp >= ?", ISS_ID, progress.Timestamp).
+		Order("timestamp asc").
+		Find(&positions).Error
+	if err != nil {
+		log.Printf("Failed to load positions for coverage: %v", err)
+		return
+	}
+
+	for i := 1; i < len(positions); i++ {
+		s.accumulate(positions[i-1], positions[i])
+	}
+}
+
+// Accumulate is registered as a collector hook. The segment between two
+// positions is sampled every COVERAGE_SAMPLE_DEG and its duration spread
+// over the samples, so fast passes still leave a continuous track.
+func (s *CoverageService) Accumulate(prev, curr *models.ISSPosition) {
+	if prev == nil {
+		return
+	}
+
+	s.accumulateMu.Lock()
+	defer s.accumulateMu.Unlock()
+	s.accumulate(prev, curr)
+}
+
+// accumulate adds one segment and moves the progress mark past it, in one
+// transaction. The caller holds accumulateMu.
+func (s *CoverageService) accumulate(prev, curr *models.ISSPosition) {
+	if curr.Timestamp <= s.accumulated {
+		return
+	}
+
+	gap := curr.Timestamp - prev.Timestamp
+	if gap <= 0 || gap > OVERFLIGHT_MAX_GAP {
+		return
+	}
+
+	dLat := curr.Latitude - prev.Latitude
+	dLon := normalizeDegrees(curr.Longitude-prev.Longitude, 180)
+	samples := max(1, int(math.Ceil(math.Max(math.Abs(dLat), math.Abs(dLon))/COVERAGE_SAMPLE_DEG)))
+	share := float64(gap) / float64(samples)
+
+	type cellKey struct {
+		day      string
+		lat, lon int
+	}
+	cells := make(map[cellKey]float64)
+	for i := range samples {
+		f := (float64(i) + 0.5) / float64(samples)
+		latIdx, lonIdx := coverageCell(prev.Latitude+f*dLat, prev.Longitude+f*dLon)
+		at := prev.Timestamp + int64(f*float64(gap))
+		day := time.Unix(at, 0).UTC().Format(OVERFLIGHT_DAY_FORMAT)
+		cells[cellKey{day, latIdx, lonIdx}] += share
+	}
+
+	rows := make([]models.CoverageCell, 0, len(cells))
+	for key, seconds := range cells {
+		rows = append(rows, models.CoverageCell{Day: key.day, LatIndex: key.lat, LonIndex: key.lon, Seconds: seconds})
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "day"}, {Name: "lat_index"}, {Name: "lon_index"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"seconds": gorm.Expr("coverage_cells.seconds + EXCLUDED.seconds"),
+			}),
+		}).Create(&rows).Error
+		if err != nil {
+			return err
+		}
+		return tx.Save(&models.CoverageProgress{ID: 1, Timestamp: curr.Timestamp}).Error
+	})
+	if err != nil {
+		log.Printf("Failed to store coverage cells: %v", err)
+		return
+	}
+	s.accumulated = curr.Timestamp
+}
+
+// ParseCoverageWindow validates a from/to pair of UTC dates (YYYY-MM-DD).
+// Empty values default to the COVERAGE_DEFAULT_DAYS days ending today.
+func ParseCoverageWindow(from, to string) (string, string, error) {
+	end := time.Now().UTC()
+	if to != "" {
+		parsed, err := time.Parse(OVERFLIGHT_DAY_FORMAT, to)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: to must be a date (YYYY-MM-DD)", ErrInvalidCoverageQuery)
+		}
+		end = parsed
+	}
+
+	start := end.AddDate(0, 0, -(COVERAGE_DEFAULT_DAYS - 1))
+	if from != "" {
+		parsed, err := time.Parse(OVERFLIGHT_DAY_FORMAT, from)
+		if err != nil {
+			return "", "", fmt.Errorf("%w: from must be a date (YYYY-MM-DD)", ErrInvalidCoverageQuery)
+		}
+		start = parsed
+	}
+
+	if start.After(end) {
+		return "", "", fmt.Errorf("%w: from must not be after to", ErrInvalidCoverageQuery)
+	}
+	if end.Sub(start) >= COVERAGE_MAX_DAYS*24*time.Hour {
+		return "", "", fmt.Errorf("%w: the window can span at most %d days", ErrInvalidCoverageQuery, COVERAGE_MAX_DAYS)
+	}
+
+	return start.Format(OVERFLIGHT_DAY_FORMAT), end.Format(OVERFLIGHT_DAY_FORMAT), nil
+}
+
+// grid sums the cells between two days (inclusive). Results are cached
+// briefly because a map view requests a dozen tiles for the same window.
+func (s *CoverageService) grid(from, to string) (*coverageGrid, error) {
+	key := from + "/" + to
+
+	s.cacheMu.Lock()
+	if cached, ok := s.cache[key]; ok && time.Now().Before(cached.expires) {
+		s.cacheMu.Unlock()
+		return cached.grid, nil
+	}
+	s.cacheMu.Unlock()
+
+	var cells []models.CoverageCell
+	err := s.db.Model(&models.CoverageCell{}).
+		Select("lat_index, lon_index, SUM(seconds) AS seconds").
+		Where("day >= ? AND day <= ?", from, to).
+		Group("lat_index, lon_index").
+		Scan(&cells).Error
+	if err != nil {
+		return nil, err
+	}
+
+	grid := &coverageGrid{seconds: make([][]float64, COVERAGE_LAT_CELLS)}
+	for i := range grid.seconds {
+		grid.seconds[i] = make([]float64, COVERAGE_LON_CELLS)
+	}
+	for _, cell := range cells {
+		if cell.LatIndex < 0 || cell.LatIndex >= COVERAGE_LAT_CELLS || cell.LonIndex < 0 || cell.LonIndex >= COVERAGE_LON_CELLS {
+			continue
+		}
+		grid.seconds[cell.LatIndex][cell.LonIndex] = cell.Seconds
+		grid.max = math.Max(grid.max, cell.Seconds)
+	}
+
+	s.cacheMu.Lock()
+	now := time.Now()
+	for k, cached := range s.cache {
+		if now.After(cached.expires) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = cachedCoverageGrid{grid: grid, expires: now.Add(COVERAGE_CACHE_TTL)}
+	s.cacheMu.Unlock()
+
+	return grid, nil
+}
+
+// GetCoverageGeoJSON returns one polygon per non-empty cell, merged into
+// cells of cellDeg degrees (a multiple of COVERAGE_CELL_DEG dividing 180).
+// density is the cell's share of the busiest cell, on a log scale.
+func (s *CoverageService) GetCoverageGeoJSON(from, to string, cellDeg int) (*models.GeoJSONFeatureCollection, error) {
+	if cellDeg <= 0 || 180%cellDeg != 0 || float64(cellDeg) < COVERAGE_CELL_DEG {
+		return nil, fmt.Errorf("%w: cell_deg must divide 180", ErrInvalidCoverageQuery)
+	}
+
+	grid, err := s.grid(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	factor := int(float64(cellDeg) / COVERAGE_CELL_DEG)
+	rows, cols := COVERAGE_LAT_CELLS/factor, COVERAGE_LON_CELLS/factor
+
+	merged := make([][]float64, rows)
+	maxSeconds := 0.0
+	for i := range merged {
+		merged[i] = make([]float64, cols)
+		for j := range merged[i] {
+			for di := range factor {
+				for dj := range factor {
+					merged[i][j] += grid.seconds[i*factor+di][j*factor+dj]
+				}
+			}
+			maxSeconds = math.Max(maxSeconds, merged[i][j])
+		}
+	}
+
+	features := []models.GeoJSONFeature{}
+	for i, row := range merged {
+		for j, seconds := range row {
+			if seconds == 0 {
+				continue
+			}
+
+			south := -90 + float64(i*cellDeg)
+			west := -180 + float64(j*cellDeg)
+			north, east := south+float64(cellDeg), west+float64(cellDeg)
+			ring := models.Coordinates{{west, south}, {east, south}, {east, north}, {west, north}, {west, south}}
+
+			features = append(features, models.NewGeoJSONFeature(models.GeoJSONPolygon, []models.Coordinates{ring}, map[string]any{
+				"seconds": math.Round(seconds),
+				"density": math.Round(logDensity(seconds, maxSeconds)*1000) / 1000,
+			}))
+		}
+	}
+
+	return models.NewGeoJSONFeatureCollection(features), nil
+}
+
+// logDensity maps seconds to [0, 1] relative to peak, compressing the range
+// so the sparse equatorial band stays visible next to the turning latitudes.
+func logDensity(seconds, peak float64) float64 {
+	if seconds <= 0 || peak <= 0 {
+		return 0
+	}
+	return math.Log1p(seconds) / math.Log1p(peak)
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoverageCell(t *testing.T) {
+	tests := []struct {
+		lat, lon       float64
+		latIdx, lonIdx int
+	}{
+		{-90, -180, 0, 0},
+		{90, 180, COVERAGE_LAT_CELLS - 1, 0},
+		{0.5, 0.5, 90, 180},
+		{51.6, 179.9, 141, 359},
+		{-51.6, 181.5, 38, 1},
+	}
+
+	for _, tt := range tests {
+		latIdx, lonIdx := coverageCell(tt.lat, tt.lon)
+		if latIdx != tt.latIdx || lonIdx != tt.lonIdx {
+			t.Errorf("coverageCell(%v, %v) = (%d, %d), want (%d, %d)", tt.lat, tt.lon, latIdx, lonIdx, tt.latIdx, tt.lonIdx)
+		}
+	}
+}
+
+func TestTileCoordinates(t *testing.T) {
+	// Zoom 0 spans the whole Web Mercator square.
+	if lat := tileLatitude(0, 0, 0); math.Abs(lat-85.0511) > 1e-4 {
+		t.Errorf("top edge latitude %f, want 85.0511", lat)
+	}
+	if lat := tileLatitude(0, 0, TILE_SIZE/2); math.Abs(lat) > 1e-9 {
+		t.Errorf("middle latitude %f, want 0", lat)
+	}
+	if lon := tileLongitude(0, 0, 0); lon != -180 {
+		t.Errorf("left edge longitude %f, want -180", lon)
+	}
+
+	// The bottom edge of one tile is the top edge of the next.
+	if a, b := tileLatitude(3, 2, TILE_SIZE), tileLatitude(3, 3, 0); math.Abs(a-b) > 1e-9 {
+		t.Errorf("tile edges differ: %f and %f", a, b)
+	}
+	if lon := tileLongitude(1, 1, 0); lon != 0 {
+		t.Errorf("tile 1/1 left edge longitude %f, want 0", lon)
+	}
+}
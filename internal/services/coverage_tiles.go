@@ -0,0 +1,90 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+)
+
+const (
+	TILE_SIZE         = 256
+	COVERAGE_MAX_ZOOM = 10
+)
+
+// coverageRamp is the heatmap colour scale, from sparse to dense. Empty
+// cells stay transparent so the layer can sit on top of any base map.
+var coverageRamp = []color.NRGBA{
+	{0x31, 0x36, 0x95, 0x90},
+	{0x45, 0x75, 0xb4, 0xa8},
+	{0x74, 0xad, 0xd1, 0xb8},
+	{0xfe, 0xe0, 0x90, 0xc8},
+	{0xf4, 0x6d, 0x43, 0xd8},
+	{0xa5, 0x00, 0x26, 0xe8},
+}
+
+func rampColor(density float64) color.NRGBA {
+	pos := math.Max(0, math.Min(1, density)) * float64(len(coverageRamp)-1)
+	i := min(int(pos), len(coverageRamp)-2)
+	f := pos - float64(i)
+
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + f*(float64(b)-float64(a))))
+	}
+	a, b := coverageRamp[i], coverageRamp[i+1]
+	return color.NRGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), lerp(a.A, b.A)}
+}
+
+// tileLatitude returns the latitude of a pixel row in Web Mercator tile y,
+// where row may be fractional.
+func tileLatitude(z, y int, row float64) float64 {
+	n := math.Exp2(float64(z))
+	return toDegrees(math.Atan(math.Sinh(math.Pi * (1 - 2*(float64(y)+row/TILE_SIZE)/n))))
+}
+
+func tileLongitude(z, x int, col float64) float64 {
+	n := math.Exp2(float64(z))
+	return (float64(x)+col/TILE_SIZE)/n*360 - 180
+}
+
+// RenderCoverageTile draws the coverage between two days as a 256x256 PNG
+// XYZ tile (Web Mercator, as used by OpenStreetMap, Leaflet and MapLibre).
+// Colours are scaled to the busiest cell in the whole window so that
+// neighbouring tiles match.
+func (s *CoverageService) RenderCoverageTile(z, x, y int, from, to string) ([]byte, error) {
+	if z < 0 || z > COVERAGE_MAX_ZOOM {
+		return nil, fmt.Errorf("%w: zoom must be between 0 and %d", ErrInvalidCoverageQuery, COVERAGE_MAX_ZOOM)
+	}
+	if n := 1 << z; x < 0 || x >= n || y < 0 || y >= n {
+		return nil, fmt.Errorf("%w: tile %d/%d/%d does not exist", ErrInvalidCoverageQuery, z, x, y)
+	}
+
+	grid, err := s.grid(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	lats := make([]float64, TILE_SIZE)
+	lons := make([]float64, TILE_SIZE)
+	for i := range TILE_SIZE {
+		lats[i] = tileLatitude(z, y, float64(i)+0.5)
+		lons[i] = tileLongitude(z, x, float64(i)+0.5)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, TILE_SIZE, TILE_SIZE))
+	for row, lat := range lats {
+		for col, lon := range lons {
+			if seconds := grid.at(lat, lon); seconds > 0 {
+				img.SetNRGBA(col, row, rampColor(logDensity(seconds, grid.max)))
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode tile: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -3,48 +3,52 @@ package services
 import (
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"log"
 	"net/url"
 	"os"
+	"slices"
+	"time"
 
 	"iss-model-backend/internal/models"
 )
 
+// The crew and portrait APIs, overridable with CREW_API_URL and
+// NASA_IMAGES_API_URL.
+var (
+	CREW_URL        = upstreamURL("CREW_API_URL", "http://api.open-notify.org/astros.json")
+	NASA_IMAGES_API = upstreamURL("NASA_IMAGES_API_URL", "https://images-api.nasa.gov/search")
+)
+
 const (
-	CREW_URL        = "http://api.open-notify.org/astros.json"
-	NASA_IMAGES_API = "https://images-api.nasa.gov/search"
+	CREW_CHECK_INTERVAL = 15 * time.Minute
+	CREW_CACHE_TTL      = 5 * time.Minute
+	PORTRAIT_CACHE_TTL  = 24 * time.Hour
 )
 
 var API_KEY = os.Getenv("NASA_API_KEY")
 
 type CrewService struct {
-	client *http.Client
+	upstream *UpstreamClient
+	hub      *EventHub
 }
 
-func NewCrewService() *CrewService {
-	return &CrewService{
-		client: &http.Client{Timeout: API_TIMEOUT},
+func NewCrewService(hub *EventHub, upstream *UpstreamClient) *CrewService {
+	service := &CrewService{
+		upstream: upstream,
+		hub:      hub,
 	}
+
+	go service.startCrewWatch()
+
+	return service
 }
 
 func (s *CrewService) GetCurrentCrew() (*models.ISSCrewResponse, error) {
-	resp, err := s.client.Get(CREW_URL)
+	body, err := s.upstream.Get(CREW_URL, CREW_CACHE_TTL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch current ISS crew: %w", err)
 	}
 
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch API: %s - %s", resp.Status, string(body))
-	}
-
 	var apiResponse models.ISSCrewResponse
 	if err := json.Unmarshal(body, &apiResponse); err != nil {
 		return nil, fmt.Errorf("failed to parse API response %w", err)
@@ -96,22 +100,11 @@ func (s *CrewService) GetNASAAstronautPortrait(astronautName string) (string, er
 
 	searchURL := NASA_IMAGES_API + "?" + params.Encode()
 
-	resp, err := s.client.Get(searchURL)
+	body, err := s.upstream.Get(searchURL, PORTRAIT_CACHE_TTL)
 	if err != nil {
 		return "", fmt.Errorf("failed to search NASA images: %w", err)
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("NASA Images API returned status: %s", resp.Status)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read NASA images response: %w", err)
-	}
-
 	var nasaResponse models.NASAImagesResponse
 	if err := json.Unmarshal(body, &nasaResponse); err != nil {
 		return "", fmt.Errorf("failed to parse NASA images response: %w", err)
@@ -119,3 +112,49 @@ func (s *CrewService) GetNASAAstronautPortrait(astronautName string) (string, er
 
 	return nasaResponse.Collection.Items[0].Links[0].Href, nil
 }
+
+// startCrewWatch polls the crew list and publishes a crew change event
+// whenever someone arrives at or leaves the ISS.
+func (s *CrewService) startCrewWatch() {
+	ticker := time.NewTicker(CREW_CHECK_INTERVAL)
+	defer ticker.Stop()
+
+	var known []string
+
+	for ; ; <-ticker.C {
+		crew, err := s.GetCurrentCrew()
+		if err != nil {
+			log.Printf("Failed to check ISS crew: %v", err)
+			continue
+		}
+
+		names := make([]string, 0, len(crew.People))
+		for _, person := range crew.People {
+			names = append(names, person.Name)
+		}
+		slices.Sort(names)
+
+		if known != nil && !slices.Equal(known, names) {
+			change := &models.CrewChange{
+				Added:   diffNames(names, known),
+				Removed: diffNames(known, names),
+				People:  crew.People,
+			}
+			log.Printf("ISS crew changed: added=%v removed=%v", change.Added, change.Removed)
+			s.hub.Publish(models.LiveEventCrewChange, change)
+		}
+
+		known = names
+	}
+}
+
+// diffNames returns the names present in a but missing from b.
+func diffNames(a, b []string) []string {
+	diff := []string{}
+	for _, name := range a {
+		if !slices.Contains(b, name) {
+			diff = append(diff, name)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,216 @@
+//go:build ignore
+
+// gen_saa writes saa_boundary.json: the South Atlantic Anomaly at ISS
+// altitude, outlined as the contour where the IGRF-13 main field strength
+// (epoch 2020.0) at 400 km drops below a threshold. Trapped protons from the
+// inner belt reach down to ISS altitude where the field is weakest, so the
+// weak-field region is the usual proxy for the high-flux region.
+//
+// The contour is traced by marching outwards from the field minimum along
+// great circles every few degrees of azimuth, which works because the
+// region is star-shaped around its minimum.
+//
+//	go run gen_saa.go [-threshold 22500] [-altitude 400] [-o saa_boundary.json]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	earthRadius = 6371.2 // IGRF reference radius, km
+	maxDegree   = 8
+)
+
+// igrf2020 holds the IGRF-13 Gauss coefficients for epoch 2020.0 in nT,
+// as {n, m, g, h}, truncated at degree 8. Higher degrees change the field at
+// 400 km by well under 100 nT.
+var igrf2020 = [][4]float64{
+	{1, 0, -29404.8, 0}, {1, 1, -1450.9, 4652.5},
+	{2, 0, -2499.6, 0}, {2, 1, 2982.0, -2991.6}, {2, 2, 1677.0, -734.6},
+	{3, 0, 1363.2, 0}, {3, 1, -2381.2, -82.1}, {3, 2, 1236.2, 241.9}, {3, 3, 525.7, -543.4},
+	{4, 0, 903.0, 0}, {4, 1, 809.5, 281.9}, {4, 2, 86.3, -158.4}, {4, 3, -309.4, 199.7}, {4, 4, 48.0, -349.7},
+	{5, 0, -234.3, 0}, {5, 1, 363.2, 47.7}, {5, 2, 187.8, 208.3}, {5, 3, -140.7, -121.2}, {5, 4, -151.2, 32.3},
+	{5, 5, 13.5, 98.9},
+	{6, 0, 66.0, 0}, {6, 1, 65.5, -19.1}, {6, 2, 72.9, 25.1}, {6, 3, -121.5, 52.8}, {6, 4, -36.2, -64.5},
+	{6, 5, 13.5, 8.9}, {6, 6, -64.7, 68.1},
+	{7, 0, 80.6, 0}, {7, 1, -76.7, -51.5}, {7, 2, -8.2, -16.9}, {7, 3, 56.5, 2.2}, {7, 4, 15.8, 23.5},
+	{7, 5, 6.4, -2.2}, {7, 6, -7.2, -27.2}, {7, 7, 9.8, -1.8},
+	{8, 0, 23.7, 0}, {8, 1, 9.7, 8.4}, {8, 2, -17.6, -15.3}, {8, 3, -0.5, 12.8}, {8, 4, -21.1, -11.7},
+	{8, 5, 15.3, 14.9}, {8, 6, 13.7, 3.6}, {8, 7, -16.5, -6.9}, {8, 8, -0.3, 2.8},
+}
+
+func main() {
+	threshold := flag.Float64("threshold", 22500, "field strength at the boundary, nT")
+	altitude := flag.Float64("altitude", 400, "altitude above the reference radius, km")
+	azimuthStep := flag.Float64("step", 5, "azimuth between vertices, degrees")
+	output := flag.String("o", "saa_boundary.json", "output file")
+	flag.Parse()
+
+	r := earthRadius + *altitude
+	minLat, minLon, minField := fieldMinimum(r)
+	log.Printf("field minimum %.0f nT at %.1f, %.1f", minField, minLat, minLon)
+	if minField >= *threshold {
+		log.Fatalf("threshold %.0f nT is below the field minimum", *threshold)
+	}
+
+	var ring [][2]float64
+	for azimuth := 0.0; azimuth < 360; azimuth += *azimuthStep {
+		distance := contourDistance(r, minLat, minLon, azimuth, *threshold)
+		lat, lon := destination(minLat, minLon, azimuth, distance)
+		ring = append(ring, [2]float64{round(lon), round(lat)})
+	}
+	ring = append(ring, ring[0])
+
+	// A bare GeoJSON Polygon, one vertex per line.
+	var body strings.Builder
+	body.WriteString("{\n  \"type\": \"Polygon\",\n  \"coordinates\": [\n    [\n")
+	for i, vertex := range ring {
+		separator := ","
+		if i == len(ring)-1 {
+			separator = ""
+		}
+		fmt.Fprintf(&body, "      [%s, %s]%s\n", formatDegrees(vertex[0]), formatDegrees(vertex[1]), separator)
+	}
+	body.WriteString("    ]\n  ]\n}\n")
+
+	if err := os.WriteFile(*output, []byte(body.String()), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("wrote %s: %d vertices, |B| < %.0f nT at %.0f km\n", *output, len(ring), *threshold, *altitude)
+}
+
+// fieldMinimum searches a 0.5° grid south of 10°N, then refines around the
+// best cell.
+func fieldMinimum(r float64) (lat, lon, field float64) {
+	field = math.Inf(1)
+	for la := -80.0; la <= 10; la += 0.5 {
+		for lo := -180.0; lo < 180; lo += 0.5 {
+			if f := fieldStrength(r, la, lo); f < field {
+				lat, lon, field = la, lo, f
+			}
+		}
+	}
+	for step := 0.25; step > 0.01; step /= 2 {
+		for _, d := range [][2]float64{{step, 0}, {-step, 0}, {0, step}, {0, -step}} {
+			if f := fieldStrength(r, lat+d[0], lon+d[1]); f < field {
+				lat, lon, field = lat+d[0], lon+d[1], f
+			}
+		}
+	}
+	return lat, lon, field
+}
+
+// contourDistance returns the angular distance in degrees from the minimum
+// to where the field first reaches threshold along an azimuth.
+func contourDistance(r, lat, lon, azimuth, threshold float64) float64 {
+	inside, outside := 0.0, 0.0
+	for d := 0.25; d < 90; d += 0.25 {
+		la, lo := destination(lat, lon, azimuth, d)
+		if fieldStrength(r, la, lo) >= threshold {
+			outside = d
+			break
+		}
+		inside = d
+	}
+	if outside == 0 {
+		log.Fatalf("no contour within 90° at azimuth %.0f", azimuth)
+	}
+
+	for range 30 {
+		mid := (inside + outside) / 2
+		la, lo := destination(lat, lon, azimuth, mid)
+		if fieldStrength(r, la, lo) >= threshold {
+			outside = mid
+		} else {
+			inside = mid
+		}
+	}
+	return (inside + outside) / 2
+}
+
+// fieldStrength returns |B| in nT at geocentric radius r (km) above a
+// geocentric latitude and longitude, differentiating the scalar potential
+// numerically.
+func fieldStrength(r, lat, lon float64) float64 {
+	theta := (90 - lat) * math.Pi / 180
+	phi := lon * math.Pi / 180
+
+	const dr, da = 1e-3, 1e-6 // km, rad
+	br := -(potential(r+dr, theta, phi) - potential(r-dr, theta, phi)) / (2 * dr)
+	bt := -(potential(r, theta+da, phi) - potential(r, theta-da, phi)) / (2 * da * r)
+	bp := -(potential(r, theta, phi+da) - potential(r, theta, phi-da)) / (2 * da * r * math.Sin(theta))
+
+	return math.Sqrt(br*br + bt*bt + bp*bp)
+}
+
+// potential is the IGRF scalar potential in nT·km.
+func potential(r, theta, phi float64) float64 {
+	p := schmidtLegendre(math.Cos(theta))
+
+	v := 0.0
+	for _, c := range igrf2020 {
+		n, m := int(c[0]), int(c[1])
+		angle := float64(m) * phi
+		v += math.Pow(earthRadius/r, float64(n+1)) * (c[2]*math.Cos(angle) + c[3]*math.Sin(angle)) * p[n][m]
+	}
+	return earthRadius * v
+}
+
+// schmidtLegendre returns the Schmidt semi-normalized associated Legendre
+// functions P[n][m](x) up to maxDegree.
+func schmidtLegendre(x float64) [maxDegree + 1][maxDegree + 1]float64 {
+	var p [maxDegree + 1][maxDegree + 1]float64
+	s := math.Sqrt(1 - x*x)
+
+	// Unnormalized functions without the Condon-Shortley phase.
+	p[0][0] = 1
+	for m := 1; m <= maxDegree; m++ {
+		p[m][m] = float64(2*m-1) * s * p[m-1][m-1]
+	}
+	for m := 0; m < maxDegree; m++ {
+		p[m+1][m] = float64(2*m+1) * x * p[m][m]
+	}
+	for m := 0; m <= maxDegree; m++ {
+		for n := m + 2; n <= maxDegree; n++ {
+			p[n][m] = (float64(2*n-1)*x*p[n-1][m] - float64(n+m-1)*p[n-2][m]) / float64(n-m)
+		}
+	}
+
+	for n := 1; n <= maxDegree; n++ {
+		for m := 1; m <= n; m++ {
+			ratio := 1.0
+			for k := n - m + 1; k <= n+m; k++ {
+				ratio *= float64(k)
+			}
+			p[n][m] *= math.Sqrt(2 / ratio)
+		}
+	}
+	return p
+}
+
+// destination returns the point at an angular distance (degrees) along an
+// azimuth from a start point on the sphere.
+func destination(lat, lon, azimuth, distance float64) (float64, float64) {
+	toRad := math.Pi / 180
+	la, lo, az, d := lat*toRad, lon*toRad, azimuth*toRad, distance*toRad
+
+	lat2 := math.Asin(math.Sin(la)*math.Cos(d) + math.Cos(la)*math.Sin(d)*math.Cos(az))
+	lon2 := lo + math.Atan2(math.Sin(az)*math.Sin(d)*math.Cos(la), math.Cos(d)-math.Sin(la)*math.Sin(lat2))
+
+	return lat2 / toRad, math.Remainder(lon2/toRad, 360)
+}
+
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+func formatDegrees(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const SUBSCRIBER_BUFFER = 64
+
+// EventListener handles an event synchronously in the publisher's goroutine.
+type EventListener func(event models.LiveEvent)
+
+// EventHub fans out live events to in-process subscribers. Publishing never
+// blocks on subscribers: one that can't keep up misses events instead of
+// stalling the collector. Consumers that must see every event register a
+// listener instead.
+type EventHub struct {
+	mu          sync.RWMutex
+	subscribers map[chan models.LiveEvent]struct{}
+	listeners   []EventListener
+}
+
+func NewEventHub() *EventHub {
+	return &EventHub{
+		subscribers: make(map[chan models.LiveEvent]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber. The returned function must be called
+// to unsubscribe and release the channel.
+func (h *EventHub) Subscribe() (<-chan models.LiveEvent, func()) {
+	ch := make(chan models.LiveEvent, SUBSCRIBER_BUFFER)
+
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers, ch)
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// OnPublish registers a listener that is called with every published event
+// before Publish returns.
+func (h *EventHub) OnPublish(listener EventListener) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners = append(h.listeners, listener)
+}
+
+func (h *EventHub) Publish(eventType string, data any) {
+	event := models.LiveEvent{
+		Type:      eventType,
+		Timestamp: time.Now().Unix(),
+		Data:      data,
+	}
+
+	h.mu.RLock()
+	listeners := h.listeners
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestEventHubListenerSeesEveryEvent(t *testing.T) {
+	hub := NewEventHub()
+	events, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+
+	var heard []models.LiveEvent
+	hub.OnPublish(func(event models.LiveEvent) { heard = append(heard, event) })
+
+	published := 3 * SUBSCRIBER_BUFFER
+	for range published {
+		hub.Publish(models.LiveEventPosition, nil)
+	}
+
+	if len(events) != SUBSCRIBER_BUFFER {
+		t.Errorf("subscriber buffered %d events, want %d", len(events), SUBSCRIBER_BUFFER)
+	}
+	if len(heard) != published {
+		t.Errorf("listener heard %d events, want all %d", len(heard), published)
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	FIXTURE_MODE_RECORD = "record"
+	FIXTURE_MODE_REPLAY = "replay"
+	FIXTURE_DEFAULT_DIR = "fixtures"
+)
+
+// FixtureConfig selects whether outbound API calls are recorded to, or
+// replayed from, fixture files in Dir. An empty Mode goes to the network.
+type FixtureConfig struct {
+	Mode string
+	Dir  string
+}
+
+func FixtureConfigFromEnv() FixtureConfig {
+	config := FixtureConfig{
+		Mode: os.Getenv("UPSTREAM_FIXTURES"),
+		Dir:  os.Getenv("UPSTREAM_FIXTURES_DIR"),
+	}
+
+	if config.Mode != "" && config.Mode != FIXTURE_MODE_RECORD && config.Mode != FIXTURE_MODE_REPLAY {
+		log.Printf("Ignoring UPSTREAM_FIXTURES=%q, expected %q or %q", config.Mode, FIXTURE_MODE_RECORD, FIXTURE_MODE_REPLAY)
+		config.Mode = ""
+	}
+	if config.Dir == "" {
+		config.Dir = FIXTURE_DEFAULT_DIR
+	}
+
+	return config
+}
+
+// fixture is a recorded response, stored as <dir>/<host>/<key>.json.
+type fixture struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// fixtureHeaders are the response headers worth recording.
+var fixtureHeaders = []string{"Content-Type", "ETag", "Last-Modified"}
+
+// FixtureTransport records responses from next to fixture files, or serves
+// them back without touching the network. Requests are matched on method and
+// full URL, so replays must use the base URLs the fixtures were recorded
+// with. A request with no fixture gets a 404.
+type FixtureTransport struct {
+	config FixtureConfig
+	next   http.RoundTripper
+	mu     sync.Mutex
+}
+
+func NewFixtureTransport(config FixtureConfig, next http.RoundTripper) *FixtureTransport {
+	return &FixtureTransport{config: config, next: next}
+}
+
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := t.path(req)
+
+	if t.config.Mode == FIXTURE_MODE_REPLAY {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fixtureResponse(req, http.StatusNotFound, nil,
+				fmt.Sprintf("no fixture for %s %s", req.Method, req.URL)), nil
+		}
+
+		var recorded fixture
+		if err := json.Unmarshal(data, &recorded); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+		}
+		return fixtureResponse(req, recorded.Status, recorded.Header, recorded.Body), nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode == http.StatusNotModified {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := fixture{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Status: resp.StatusCode,
+		Header: make(http.Header),
+		Body:   string(body),
+	}
+	for _, name := range fixtureHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			recorded.Header.Set(name, value)
+		}
+	}
+	if err := t.save(path, &recorded); err != nil {
+		log.Printf("Failed to record fixture for %s: %v", req.URL, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// path names the fixture file of a request after a hash of its method and
+// URL, which is also recorded inside the file.
+func (t *FixtureTransport) path(req *http.Request) string {
+	sum := sha256.Sum256([]byte(req.Method + " " + req.URL.String()))
+	name := strings.ToLower(req.Method) + "-" + hex.EncodeToString(sum[:8]) + ".json"
+	return filepath.Join(t.config.Dir, req.URL.Host, name)
+}
+
+func (t *FixtureTransport) save(path string, recorded *fixture) error {
+	data, err := json.MarshalIndent(recorded, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func fixtureResponse(req *http.Request, status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
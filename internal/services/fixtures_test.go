@@ -0,0 +1,51 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFixtureReplay(t *testing.T) {
+	client := NewUpstreamClient(FixtureConfig{Mode: FIXTURE_MODE_REPLAY, Dir: "testdata/fixtures"})
+
+	crew, err := (&CrewService{upstream: client}).GetCurrentCrew()
+	if err != nil {
+		t.Fatalf("GetCurrentCrew: %v", err)
+	}
+	if len(crew.People) != 3 || crew.People[0].Name != "Oleg Kononenko" {
+		t.Errorf("replayed crew = %+v, want the 3 recorded ISS crew members", crew.People)
+	}
+
+	position, err := (&ISSService{upstream: client, satelliteID: ISS_ID}).fetchFromAPI(1364069476, "kilometers")
+	if err != nil {
+		t.Fatalf("fetchFromAPI: %v", err)
+	}
+	if position.Timestamp != 1364069476 || position.Altitude != 408.05526028199 {
+		t.Errorf("replayed position = %+v", position)
+	}
+
+	if _, err := (&ISSService{upstream: client, satelliteID: ISS_ID}).fetchFromAPI(1364069477, "kilometers"); err == nil {
+		t.Error("fetchFromAPI without a fixture succeeded")
+	}
+}
+
+func TestFixtureRecord(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(r.URL.Query().Get("q")))
+	}))
+	dir := t.TempDir()
+
+	recording := NewUpstreamClient(FixtureConfig{Mode: FIXTURE_MODE_RECORD, Dir: dir})
+	if body, err := recording.Get(server.URL+"?q=tiangong", 0); err != nil || string(body) != "tiangong" {
+		t.Fatalf("recording Get = %q, %v", body, err)
+	}
+	server.Close()
+
+	replaying := NewUpstreamClient(FixtureConfig{Mode: FIXTURE_MODE_REPLAY, Dir: dir})
+	if body, err := replaying.Get(server.URL+"?q=tiangong", time.Hour); err != nil || string(body) != "tiangong" {
+		t.Errorf("replayed Get = %q, %v; want the recorded body with the server gone", body, err)
+	}
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	FRAME_ECEF  = "ecef"
+	FRAME_ECI   = "eci"
+	FRAME_TEME  = "teme"
+	FRAME_J2000 = "j2000"
+)
+
+const arcsecToRadians = math.Pi / (180 * 3600)
+
+var ErrInvalidFrame = errors.New("invalid frame")
+
+// ParseFrame validates a frame query value. An empty value means no
+// Cartesian state was requested.
+func ParseFrame(frame string) (string, error) {
+	frame = strings.ToLower(frame)
+	switch frame {
+	case "", FRAME_ECEF, FRAME_TEME, FRAME_J2000:
+		return frame, nil
+	case FRAME_ECI:
+		return FRAME_J2000, nil
+	}
+	return "", fmt.Errorf("%w: %q, expected ecef, eci, teme or j2000", ErrInvalidFrame, frame)
+}
+
+// nutation returns the nutation in longitude and obliquity and the mean
+// obliquity of the ecliptic, all in radians, at t Julian centuries of TT.
+// Only the leading terms of the IAU 1980 series are used (Meeus ch. 22),
+// which is good to about half an arcsecond.
+func nutation(t float64) (dpsi, deps, meanEps float64) {
+	omega := toRadians(125.04452 - 1934.136261*t)
+	sunLon := toRadians(280.4665 + 36000.7698*t)
+	moonLon := toRadians(218.3165 + 481267.8813*t)
+
+	dpsi = -17.20*math.Sin(omega) - 1.32*math.Sin(2*sunLon) -
+		0.23*math.Sin(2*moonLon) + 0.21*math.Sin(2*omega)
+	deps = 9.20*math.Cos(omega) + 0.57*math.Cos(2*sunLon) +
+		0.10*math.Cos(2*moonLon) - 0.09*math.Cos(2*omega)
+	meanEps = 84381.448 - 46.8150*t - 0.00059*t*t + 0.001813*t*t*t
+
+	return dpsi * arcsecToRadians, deps * arcsecToRadians, meanEps * arcsecToRadians
+}
+
+// precession returns the rotation from the mean equator and equinox of date
+// to J2000 (IAU 1976).
+func precession(t float64) mat3 {
+	zeta := (2306.2181*t + 0.30188*t*t + 0.017998*t*t*t) * arcsecToRadians
+	theta := (2004.3109*t - 0.42665*t*t - 0.041833*t*t*t) * arcsecToRadians
+	z := (2306.2181*t + 1.09468*t*t + 0.018203*t*t*t) * arcsecToRadians
+
+	return rotZ(zeta).mul(rotY(-theta)).mul(rotZ(z))
+}
+
+// ecefToFrame returns the rotation taking ECEF vectors into frame at t.
+// Polar motion and UT1-UTC are ignored, which costs a few meters at most.
+//
+//   - teme: ROT3(-GMST), the frame SGP4 works in.
+//   - j2000: precession x nutation x ROT3(-GAST).
+func ecefToFrame(frame string, t time.Time) mat3 {
+	switch frame {
+	case FRAME_TEME:
+		return rotZ(-gmstRadians(t))
+	case FRAME_J2000:
+		centuries := julianCenturiesTT(t)
+		dpsi, deps, meanEps := nutation(centuries)
+		n := rotX(-meanEps).mul(rotZ(dpsi)).mul(rotX(meanEps + deps))
+		return precession(centuries).mul(n).mul(rotZ(-gastRadians(t)))
+	}
+	return mat3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+}
+
+// stateInFrame expresses an ECEF state in frame. Inertial frames get the
+// Earth-rotation term added to the velocity before rotating.
+func stateInFrame(frame string, st *orbitalState) (r, v vec3) {
+	if frame == FRAME_ECEF {
+		return st.R, st.V
+	}
+
+	m := ecefToFrame(frame, time.Unix(st.Timestamp, 0).UTC())
+	return m.mulVec(st.R), m.mulVec(st.inertialVelocity())
+}
+
+// stateVector builds the response model, converting to miles when units asks
+// for it.
+func stateVector(frame, units string, st *orbitalState) *models.StateVector {
+	r, v := stateInFrame(frame, st)
+
+	distanceUnit, velocityUnit := "km", "km/s"
+	if units == "miles" {
+		r = r.scale(KM_TO_MILES)
+		v = v.scale(KM_TO_MILES)
+		distanceUnit, velocityUnit = "mi", "mi/s"
+	}
+
+	return &models.StateVector{
+		Frame:        frame,
+		Epoch:        st.Timestamp,
+		Position:     r.toModel(),
+		Velocity:     v.toModel(),
+		PositionUnit: distanceUnit,
+		VelocityUnit: velocityUnit,
+	}
+}
+
+// newPositionWithState wraps position with the fields that need no lookup:
+// the SAA flag and the nearest city.
+func newPositionWithState(position *models.ISSPosition) *models.ISSPositionWithState {
+	return &models.ISSPositionWithState{
+		ISSPosition: *position,
+		InSAA:       InSAA(position.Latitude, position.Longitude),
+		NearestCity: NearestCity(position.Latitude, position.Longitude),
+	}
+}
+
+// WithState flags whether position is inside the SAA, adds the nearest city
+// and attaches its state vector in frame, unless frame is empty.
+func (s *ISSService) WithState(position *models.ISSPosition, frame string) (*models.ISSPositionWithState, error) {
+	result := newPositionWithState(position)
+	if frame == "" {
+		return result, nil
+	}
+
+	state, err := s.stateAt(position.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ISS state: %w", err)
+	}
+
+	result.State = stateVector(frame, position.Units, state)
+	return result, nil
+}
+
+// WithStates attaches state vectors to a time-ordered series. Velocities
+// come from the neighbouring samples, so only isolated samples cost a lookup.
+func (s *ISSService) WithStates(positions []*models.ISSPosition, frame string) ([]*models.ISSPositionWithState, error) {
+	results := make([]*models.ISSPositionWithState, len(positions))
+
+	for i, position := range positions {
+		if frame == "" {
+			results[i] = newPositionWithState(position)
+			continue
+		}
+
+		prev := positions[max(i-1, 0)]
+		next := positions[min(i+1, len(positions)-1)]
+		if next.Timestamp == prev.Timestamp || next.Timestamp-prev.Timestamp > 2*STATE_SEARCH_WINDOW {
+			result, err := s.WithState(position, frame)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = result
+			continue
+		}
+
+		prevKm, currKm, nextKm := *prev, *position, *next
+		s.convertUnits(&prevKm, "kilometers")
+		s.convertUnits(&currKm, "kilometers")
+		s.convertUnits(&nextKm, "kilometers")
+
+		state := interpolateState(&prevKm, &nextKm, position.Timestamp)
+		state.R = geodeticToECEF(currKm.Latitude, currKm.Longitude, currKm.Altitude)
+
+		results[i] = newPositionWithState(position)
+		results[i].State = stateVector(frame, position.Units, state)
+	}
+
+	return results, nil
+}
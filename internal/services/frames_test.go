@@ -0,0 +1,72 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGMST(t *testing.T) {
+	// Vallado, Example 3-5: 1992-08-20 12:14 UT1.
+	at := time.Date(1992, 8, 20, 12, 14, 0, 0, time.UTC)
+	if got := toDegrees(gmstRadians(at)); math.Abs(got-152.578787810) > 1e-6 {
+		t.Errorf("GMST = %.9f°, want 152.578787810°", got)
+	}
+}
+
+func TestTimeScales(t *testing.T) {
+	at := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	conv := ConvertTime(at)
+
+	if conv.TAIMinusUTC != 37 {
+		t.Errorf("TAI-UTC = %v, want 37", conv.TAIMinusUTC)
+	}
+	if conv.TAI != "2024-03-01T00:00:37.000" || conv.TT != "2024-03-01T00:01:09.184" {
+		t.Errorf("TAI %s / TT %s", conv.TAI, conv.TT)
+	}
+	if conv.GPSWeek != 2303 || conv.GPSSecondsOfWeek != 432018 {
+		t.Errorf("GPS week %d, seconds %v, want 2303 and 432018", conv.GPSWeek, conv.GPSSecondsOfWeek)
+	}
+	if conv.ModifiedJulianUTC != 60370 {
+		t.Errorf("MJD = %v, want 60370", conv.ModifiedJulianUTC)
+	}
+
+	for scale, value := range map[string]string{
+		TIME_SCALE_TAI: conv.TAI,
+		TIME_SCALE_TT:  conv.TT,
+		TIME_SCALE_GPS: "1393286418",
+		TIME_SCALE_MJD: "60370",
+	} {
+		parsed, err := ParseTime(value, scale)
+		if err != nil {
+			t.Fatalf("%s: %v", scale, err)
+		}
+		if d := parsed.Sub(at); d > time.Millisecond || d < -time.Millisecond {
+			t.Errorf("%s %s parsed to %s", scale, value, parsed)
+		}
+	}
+}
+
+func TestInertialFrames(t *testing.T) {
+	st := &orbitalState{
+		Timestamp: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		R:         geodeticToECEF(30, 45, 420),
+		V:         vec3{-4.5, 3.1, 4.6},
+	}
+
+	teme, vTeme := stateInFrame(FRAME_TEME, st)
+	j2000, vJ2000 := stateInFrame(FRAME_J2000, st)
+
+	if math.Abs(teme.norm()-st.R.norm()) > 1e-9 || math.Abs(j2000.norm()-st.R.norm()) > 1e-9 {
+		t.Fatalf("rotations changed the radius")
+	}
+	if math.Abs(vTeme.norm()-vJ2000.norm()) > 1e-9 || vTeme.norm() <= st.V.norm() {
+		t.Errorf("inertial speeds %f / %f vs ECEF %f", vTeme.norm(), vJ2000.norm(), st.V.norm())
+	}
+
+	// 24 years of precession separate the two frames by roughly 0.33°.
+	angle := toDegrees(math.Acos(teme.unit().dot(j2000.unit())))
+	if angle < 0.1 || angle > 0.5 {
+		t.Errorf("TEME and J2000 differ by %f°", angle)
+	}
+}
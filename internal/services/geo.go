@@ -0,0 +1,151 @@
+package services
+
+import (
+	"math"
+
+	"iss-model-backend/internal/models"
+)
+
+const EARTH_RADIUS_KM = 6371.0
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func toDegrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// haversineKm returns the great-circle distance between two points in kilometers.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EARTH_RADIUS_KM * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// initialBearing returns the compass bearing in degrees [0, 360) of the
+// great circle from the first point towards the second.
+func initialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	lat1Rad, lat2Rad := toRadians(lat1), toRadians(lat2)
+	dLon := toRadians(lon2 - lon1)
+
+	y := math.Sin(dLon) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(dLon)
+
+	return normalizeDegrees(toDegrees(math.Atan2(y, x)), 360)
+}
+
+// pointInPolygon reports whether the point lies inside the ring using ray
+// casting. Vertex longitudes are unwrapped first, so rings crossing the
+// antimeridian work as long as consecutive vertices are less than 180° apart.
+func pointInPolygon(lat, lon float64, ring models.Coordinates) bool {
+	if len(ring) < 3 {
+		return false
+	}
+
+	unwrapped := make([][2]float64, len(ring))
+	unwrapped[0] = ring[0]
+	for i := 1; i < len(ring); i++ {
+		prevLon := unwrapped[i-1][0]
+		curLon := ring[i][0]
+		for curLon-prevLon > 180 {
+			curLon -= 360
+		}
+		for curLon-prevLon < -180 {
+			curLon += 360
+		}
+		unwrapped[i] = [2]float64{curLon, ring[i][1]}
+	}
+
+	for _, shift := range []float64{0, -360, 360} {
+		if rayCast(lat, lon+shift, unwrapped) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func rayCast(lat, lon float64, ring [][2]float64) bool {
+	inside := false
+	j := len(ring) - 1
+
+	for i := 0; i < len(ring); i++ {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+
+		if (yi > lat) != (yj > lat) && lon < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+		j = i
+	}
+
+	return inside
+}
+
+const (
+	WGS84_A_KM          = 6378.137
+	WGS84_F             = 1 / 298.257223563
+	EARTH_ROTATION_RATE = 7.2921150e-5 // rad/s
+	wgs84EccentricitySq = WGS84_F * (2 - WGS84_F)
+)
+
+// geodeticToECEF converts WGS84 geodetic coordinates (degrees, km) to an
+// Earth-centered, Earth-fixed position in kilometers.
+func geodeticToECEF(lat, lon, alt float64) vec3 {
+	latRad := toRadians(lat)
+	lonRad := toRadians(lon)
+
+	sinLat := math.Sin(latRad)
+	n := WGS84_A_KM / math.Sqrt(1-wgs84EccentricitySq*sinLat*sinLat)
+
+	return vec3{
+		(n + alt) * math.Cos(latRad) * math.Cos(lonRad),
+		(n + alt) * math.Cos(latRad) * math.Sin(lonRad),
+		(n*(1-wgs84EccentricitySq) + alt) * sinLat,
+	}
+}
+
+// ecefToGeodetic is the inverse of geodeticToECEF (Bowring's method, accurate
+// to well below a meter at LEO altitudes).
+func ecefToGeodetic(r vec3) (lat, lon, alt float64) {
+	b := WGS84_A_KM * (1 - WGS84_F)
+	ep2 := (WGS84_A_KM*WGS84_A_KM - b*b) / (b * b)
+
+	p := math.Hypot(r[0], r[1])
+	theta := math.Atan2(r[2]*WGS84_A_KM, p*b)
+
+	latRad := math.Atan2(
+		r[2]+ep2*b*math.Pow(math.Sin(theta), 3),
+		p-wgs84EccentricitySq*WGS84_A_KM*math.Pow(math.Cos(theta), 3),
+	)
+	lonRad := math.Atan2(r[1], r[0])
+
+	sinLat := math.Sin(latRad)
+	n := WGS84_A_KM / math.Sqrt(1-wgs84EccentricitySq*sinLat*sinLat)
+
+	if math.Abs(math.Cos(latRad)) > 1e-9 {
+		alt = p/math.Cos(latRad) - n
+	} else {
+		alt = math.Abs(r[2]) - b
+	}
+
+	return toDegrees(latRad), toDegrees(lonRad), alt
+}
+
+// subpointDirection returns the ECEF unit vector pointing from the Earth's
+// center through a subpoint, e.g. the solar subpoint stored with positions.
+func subpointDirection(lat, lon float64) vec3 {
+	latRad := toRadians(lat)
+	lonRad := toRadians(lon)
+
+	return vec3{
+		math.Cos(latRad) * math.Cos(lonRad),
+		math.Cos(latRad) * math.Sin(lonRad),
+		math.Sin(latRad),
+	}
+}
@@ -0,0 +1,222 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidGeofence   = errors.New("invalid geofence")
+	ErrDuplicateGeofence = errors.New("duplicate geofence")
+)
+
+type GeofenceService struct {
+	db  *gorm.DB
+	hub *EventHub
+}
+
+func NewGeofenceService(db *gorm.DB, hub *EventHub) *GeofenceService {
+	return &GeofenceService{db: db, hub: hub}
+}
+
+func (s *GeofenceService) CreateGeofence(geofence *models.Geofence) (*models.Geofence, error) {
+	if err := validateGeofence(geofence); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(geofence.Name, 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(geofence).Error; err != nil {
+		return nil, err
+	}
+	return geofence, nil
+}
+
+func (s *GeofenceService) GetGeofenceByID(id uint) (*models.Geofence, error) {
+	var geofence models.Geofence
+	if err := s.db.First(&geofence, id).Error; err != nil {
+		return nil, err
+	}
+	return &geofence, nil
+}
+
+func (s *GeofenceService) GetAllGeofences() ([]models.Geofence, error) {
+	var geofences []models.Geofence
+	if err := s.db.Order("name asc").Find(&geofences).Error; err != nil {
+		return nil, err
+	}
+	return geofences, nil
+}
+
+func (s *GeofenceService) UpdateGeofence(id uint, update *models.Geofence) (*models.Geofence, error) {
+	geofence, err := s.GetGeofenceByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateGeofence(update); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(update.Name, id); err != nil {
+		return nil, err
+	}
+
+	geofence.Name = update.Name
+	geofence.Description = update.Description
+	geofence.Shape = update.Shape
+	geofence.CenterLat = update.CenterLat
+	geofence.CenterLon = update.CenterLon
+	geofence.RadiusKm = update.RadiusKm
+	geofence.Polygon = update.Polygon
+	geofence.Active = update.Active
+
+	if err := s.db.Save(geofence).Error; err != nil {
+		return nil, err
+	}
+	return geofence, nil
+}
+
+func (s *GeofenceService) DeleteGeofence(id uint) error {
+	if err := s.db.Delete(&models.Geofence{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkNameAvailable reports whether a geofence other than id already uses
+// name.
+func (s *GeofenceService) checkNameAvailable(name string, id uint) error {
+	var count int64
+	if err := s.db.Model(&models.Geofence{}).Where("name = ? AND id <> ?", name, id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: a geofence named %q already exists", ErrDuplicateGeofence, name)
+	}
+	return nil
+}
+
+// GetEvents returns crossing events, newest first. Zero values disable the
+// corresponding filter.
+func (s *GeofenceService) GetEvents(geofenceID uint, from, to int64, limit int) ([]models.GeofenceEvent, error) {
+	query := s.db.Model(&models.GeofenceEvent{})
+
+	if geofenceID != 0 {
+		query = query.Where("geofence_id = ?", geofenceID)
+	}
+	if from != 0 {
+		query = query.Where("timestamp >= ?", from)
+	}
+	if to != 0 {
+		query = query.Where("timestamp <= ?", to)
+	}
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	var events []models.GeofenceEvent
+	if err := query.Order("timestamp desc").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// CheckCrossing is registered as a collector hook. It compares the previous
+// and current position against every active geofence and records an enter or
+// exit event for each boundary crossed.
+func (s *GeofenceService) CheckCrossing(prev, curr *models.ISSPosition) {
+	if prev == nil {
+		return
+	}
+
+	var geofences []models.Geofence
+	if err := s.db.Where("active = ?", true).Find(&geofences).Error; err != nil {
+		log.Printf("Failed to load geofences: %v", err)
+		return
+	}
+
+	for _, geofence := range geofences {
+		wasInside := geofenceContains(&geofence, prev.Latitude, prev.Longitude)
+		isInside := geofenceContains(&geofence, curr.Latitude, curr.Longitude)
+
+		if wasInside == isInside {
+			continue
+		}
+
+		eventType := models.GeofenceEventExit
+		if isInside {
+			eventType = models.GeofenceEventEnter
+		}
+
+		event := &models.GeofenceEvent{
+			GeofenceID:   geofence.ID,
+			GeofenceName: geofence.Name,
+			EventType:    eventType,
+			Latitude:     curr.Latitude,
+			Longitude:    curr.Longitude,
+			Timestamp:    curr.Timestamp,
+		}
+
+		if err := s.db.Create(event).Error; err != nil {
+			log.Printf("Failed to store geofence event: %v", err)
+			continue
+		}
+
+		log.Printf("ISS %s geofence %q at timestamp=%d", eventType, geofence.Name, curr.Timestamp)
+
+		s.hub.Publish(models.LiveEventGeofence, event)
+	}
+}
+
+func geofenceContains(geofence *models.Geofence, lat, lon float64) bool {
+	switch geofence.Shape {
+	case models.GeofenceShapeCircle:
+		return haversineKm(geofence.CenterLat, geofence.CenterLon, lat, lon) <= geofence.RadiusKm
+	case models.GeofenceShapePolygon:
+		return pointInPolygon(lat, lon, geofence.Polygon)
+	}
+	return false
+}
+
+func validateGeofence(geofence *models.Geofence) error {
+	if geofence.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidGeofence)
+	}
+
+	switch geofence.Shape {
+	case models.GeofenceShapeCircle:
+		if geofence.RadiusKm <= 0 {
+			return fmt.Errorf("%w: radius_km must be positive", ErrInvalidGeofence)
+		}
+		if !validLatLon(geofence.CenterLat, geofence.CenterLon) {
+			return fmt.Errorf("%w: center is not a valid coordinate", ErrInvalidGeofence)
+		}
+		geofence.Polygon = models.Coordinates{}
+	case models.GeofenceShapePolygon:
+		if len(geofence.Polygon) < 3 {
+			return fmt.Errorf("%w: polygon needs at least 3 points", ErrInvalidGeofence)
+		}
+		for _, point := range geofence.Polygon {
+			if !validLatLon(point[1], point[0]) {
+				return fmt.Errorf("%w: polygon point [%g, %g] is not a valid [lon, lat] coordinate",
+					ErrInvalidGeofence, point[0], point[1])
+			}
+		}
+		geofence.CenterLat, geofence.CenterLon, geofence.RadiusKm = 0, 0, 0
+	default:
+		return fmt.Errorf("%w: shape must be %q or %q", ErrInvalidGeofence,
+			models.GeofenceShapeCircle, models.GeofenceShapePolygon)
+	}
+
+	return nil
+}
+
+func validLatLon(lat, lon float64) bool {
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
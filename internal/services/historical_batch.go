@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	HISTORICAL_BATCH_MAX    = 500
+	HISTORICAL_BATCH_WINDOW = 4 * 3600 // seconds back/forward, as for single lookups
+	// HISTORICAL_INTERPOLATION_GAP bounds the spacing of two stored samples
+	// a timestamp between them is interpolated from. Collection runs every
+	// 10 s, so this bridges a few missed samples.
+	HISTORICAL_INTERPOLATION_GAP = 60
+	// wheretheiss.at takes up to 10 timestamps per positions request.
+	UPSTREAM_BATCH_SIZE = 10
+	// HISTORICAL_BATCH_MAX_UPSTREAM_CALLS keeps a batch, paced by the
+	// upstream rate limit, well inside the server's write timeout.
+	HISTORICAL_BATCH_MAX_UPSTREAM_CALLS = 20
+)
+
+var ErrInvalidHistoricalBatch = errors.New("invalid historical batch")
+
+// GetHistoricalBatch resolves the positions at timestamps, in request order,
+// with state vectors in frame unless it is empty. A stored sample at the
+// exact second is used as is, a timestamp between close stored samples is
+// interpolated, and the rest is fetched from the API in batches and stored.
+func (s *ISSService) GetHistoricalBatch(timestamps []int64, units, frame string) (*models.HistoricalBatchResponse, error) {
+	if units == "" {
+		units = "kilometers"
+	}
+	if len(timestamps) == 0 {
+		return nil, fmt.Errorf("%w: no timestamps", ErrInvalidHistoricalBatch)
+	}
+	if len(timestamps) > HISTORICAL_BATCH_MAX {
+		return nil, fmt.Errorf("%w: %d timestamps, at most %d allowed",
+			ErrInvalidHistoricalBatch, len(timestamps), HISTORICAL_BATCH_MAX)
+	}
+
+	now := s.Now().Unix()
+	for _, ts := range timestamps {
+		if ts < now-HISTORICAL_BATCH_WINDOW || ts > now+HISTORICAL_BATCH_WINDOW {
+			return nil, fmt.Errorf("%w: timestamp %d outside retention window (4 hours back/forward)",
+				ErrInvalidHistoricalBatch, ts)
+		}
+	}
+
+	unique := slices.Clone(timestamps)
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+
+	var stored []*models.ISSPosition
+	err := s.positions().Where("timestamp BETWEEN ? AND ?", unique[0]-HISTORICAL_INTERPOLATION_GAP, unique[len(unique)-1]+HISTORICAL_INTERPOLATION_GAP).
+		Order("timestamp asc").
+		Find(&stored).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range stored {
+		s.convertUnits(position, "kilometers")
+	}
+
+	resolved, sources, missing := resolveFromStored(unique, stored)
+
+	if calls := (len(missing) + UPSTREAM_BATCH_SIZE - 1) / UPSTREAM_BATCH_SIZE; calls > HISTORICAL_BATCH_MAX_UPSTREAM_CALLS {
+		return nil, fmt.Errorf("%w: %d timestamps are not covered by stored data, at most %d can be fetched per batch",
+			ErrInvalidHistoricalBatch, len(missing), HISTORICAL_BATCH_MAX_UPSTREAM_CALLS*UPSTREAM_BATCH_SIZE)
+	}
+
+	calls := 0
+	for chunk := range slices.Chunk(missing, UPSTREAM_BATCH_SIZE) {
+		positions, err := s.fetchPositionsFromAPI(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch historical positions: %w", err)
+		}
+		calls++
+
+		for _, position := range positions {
+			s.positions().Where("timestamp = ?", position.Timestamp).FirstOrCreate(position)
+			resolved[position.Timestamp] = position
+			sources[position.Timestamp] = models.HistoricalSourceUpstream
+		}
+	}
+
+	series := make([]*models.ISSPosition, len(unique))
+	for i, ts := range unique {
+		position, ok := resolved[ts]
+		if !ok {
+			return nil, fmt.Errorf("API returned no position for timestamp %d", ts)
+		}
+		copied := *position
+		s.convertUnits(&copied, units)
+		series[i] = &copied
+	}
+
+	withStates, err := s.WithStates(series, frame)
+	if err != nil {
+		return nil, err
+	}
+	byTimestamp := make(map[int64]*models.ISSPositionWithState, len(withStates))
+	for _, result := range withStates {
+		byTimestamp[result.Timestamp] = result
+	}
+
+	response := &models.HistoricalBatchResponse{
+		Positions:     make([]models.HistoricalBatchPosition, len(timestamps)),
+		UpstreamCalls: calls,
+	}
+	for i, ts := range timestamps {
+		response.Positions[i] = models.HistoricalBatchPosition{
+			ISSPositionWithState: *byTimestamp[ts],
+			Source:               sources[ts],
+		}
+	}
+
+	return response, nil
+}
+
+// resolveFromStored matches sorted, distinct timestamps against stored
+// samples in kilometers, oldest first. It returns the positions it could
+// resolve with their sources, and the timestamps left over.
+func resolveFromStored(timestamps []int64, stored []*models.ISSPosition) (map[int64]*models.ISSPosition, map[int64]string, []int64) {
+	resolved := make(map[int64]*models.ISSPosition, len(timestamps))
+	sources := make(map[int64]string, len(timestamps))
+	var missing []int64
+
+	for _, ts := range timestamps {
+		i := sort.Search(len(stored), func(i int) bool { return stored[i].Timestamp >= ts })
+		switch {
+		case i < len(stored) && stored[i].Timestamp == ts:
+			resolved[ts] = stored[i]
+			sources[ts] = models.HistoricalSourceStored
+		case i > 0 && i < len(stored) && stored[i].Timestamp-stored[i-1].Timestamp <= HISTORICAL_INTERPOLATION_GAP:
+			resolved[ts] = interpolatePosition(stored[i-1], stored[i], ts)
+			sources[ts] = models.HistoricalSourceInterpolated
+		default:
+			missing = append(missing, ts)
+		}
+	}
+
+	return resolved, sources, missing
+}
+
+// interpolatePosition returns the position at timestamp between two samples
+// in kilometers. The ground track follows the orbit, the solar subpoint is
+// computed for timestamp in the upstream [0, 360) longitude convention, the
+// other scalar fields are interpolated linearly and visibility comes from
+// the nearer sample.
+func interpolatePosition(a, b *models.ISSPosition, timestamp int64) *models.ISSPosition {
+	state := interpolateState(a, b, timestamp)
+	frac := float64(timestamp-a.Timestamp) / float64(b.Timestamp-a.Timestamp)
+	lerp := func(x, y float64) float64 { return x + (y-x)*frac }
+	solarLat, solarLon := solarSubpoint(time.Unix(timestamp, 0).UTC())
+
+	return &models.ISSPosition{
+		SatelliteID: a.SatelliteID,
+		Name:        a.Name,
+		Latitude:    state.Latitude,
+		Longitude:   state.Longitude,
+		Altitude:    state.Altitude,
+		Velocity:    lerp(a.Velocity, b.Velocity),
+		Visibility:  state.Visibility,
+		Footprint:   lerp(a.Footprint, b.Footprint),
+		Timestamp:   timestamp,
+		Daynum:      lerp(a.Daynum, b.Daynum),
+		SolarLat:    solarLat,
+		SolarLon:    normalizeDegrees(solarLon, 360),
+		Units:       "kilometers",
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestResolveFromStored(t *testing.T) {
+	const ts = 1740000000
+	track := validationTrack(ts)
+	stored := []*models.ISSPosition{track[0], track[2], track[3], track[20]}
+	for _, position := range stored {
+		position.SatelliteID = 48274
+	}
+
+	resolved, sources, missing := resolveFromStored([]int64{ts, ts + 10, ts + 30, ts + 100, ts + 300}, stored)
+
+	if sources[ts] != models.HistoricalSourceStored || sources[ts+30] != models.HistoricalSourceStored {
+		t.Errorf("exact matches resolved as %q and %q, want stored", sources[ts], sources[ts+30])
+	}
+	if sources[ts+10] != models.HistoricalSourceInterpolated {
+		t.Fatalf("ts+10 resolved as %q, want interpolated", sources[ts+10])
+	}
+	if !slices.Equal(missing, []int64{ts + 100, ts + 300}) {
+		t.Errorf("missing = %v, want the timestamps in the gap and past the last sample", missing)
+	}
+
+	got, want := resolved[ts+10], track[1]
+	if got.SatelliteID != 48274 {
+		t.Errorf("interpolated satellite_id = %d, want the samples' 48274", got.SatelliteID)
+	}
+	if math.Abs(got.Latitude-want.Latitude) > 0.01 || math.Abs(got.Longitude-want.Longitude) > 0.01 || math.Abs(got.Altitude-want.Altitude) > 0.1 {
+		t.Errorf("interpolated %.4f, %.4f, %.2f km, want %.4f, %.4f, %.2f km",
+			got.Latitude, got.Longitude, got.Altitude, want.Latitude, want.Longitude, want.Altitude)
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	STATE_SEARCH_WINDOW = 60 // seconds around the requested timestamp
+	STATE_API_HALF_STEP = 5  // seconds, for central differences from the API
+	ATTITUDE_MODE       = "LVLH +XVV"
+	ATTITUDE_FRAME      = "ECEF"
+	TRRJ_LIMIT          = 115.0 // degrees of radiator joint travel each way
+)
+
+// orbitalState is the ISS position and velocity at a single instant,
+// derived from stored positions.
+type orbitalState struct {
+	Timestamp  int64
+	R          vec3 // ECEF position, km
+	V          vec3 // ECEF velocity relative to the rotating Earth, km/s
+	Sun        vec3 // ECEF unit vector towards the Sun
+	Latitude   float64
+	Longitude  float64
+	Altitude   float64
+	Visibility string
+}
+
+// inertialVelocity returns the velocity relative to a non-rotating frame,
+// expressed in ECEF axes.
+func (st *orbitalState) inertialVelocity() vec3 {
+	omega := vec3{0, 0, EARTH_ROTATION_RATE}
+	return st.V.add(omega.cross(st.R))
+}
+
+// stateAt interpolates the ISS state between the two stored positions that
+// bracket timestamp. Velocity comes from the difference between them. When
+// no bracketing pair is stored, both samples are fetched from the API.
+func (s *ISSService) stateAt(timestamp int64) (*orbitalState, error) {
+	var samples []*models.ISSPosition
+	err := s.positions().Where("timestamp BETWEEN ? AND ?", timestamp-STATE_SEARCH_WINDOW, timestamp+STATE_SEARCH_WINDOW).
+		Order("timestamp asc").
+		Find(&samples).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i+1 < len(samples); i++ {
+		if samples[i].Timestamp <= timestamp && samples[i+1].Timestamp >= timestamp {
+			s.convertUnits(samples[i], "kilometers")
+			s.convertUnits(samples[i+1], "kilometers")
+			return interpolateState(samples[i], samples[i+1], timestamp), nil
+		}
+	}
+
+	before, err := s.fetchFromAPI(timestamp-STATE_API_HALF_STEP, "kilometers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch position for state: %w", err)
+	}
+	after, err := s.fetchFromAPI(timestamp+STATE_API_HALF_STEP, "kilometers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch position for state: %w", err)
+	}
+
+	return interpolateState(before, after, timestamp), nil
+}
+
+func interpolateState(a, b *models.ISSPosition, timestamp int64) *orbitalState {
+	ra := geodeticToECEF(a.Latitude, a.Longitude, a.Altitude)
+	rb := geodeticToECEF(b.Latitude, b.Longitude, b.Altitude)
+
+	dt := float64(b.Timestamp - a.Timestamp)
+	frac := 0.0
+	velocity := vec3{}
+	if dt > 0 {
+		frac = float64(timestamp-a.Timestamp) / dt
+		velocity = rb.sub(ra).scale(1 / dt)
+
+		// The chord is parallel to the velocity at the midpoint; turn it by
+		// the angle swept since then so it stays tangent at timestamp.
+		swept := math.Acos(math.Max(-1, math.Min(1, ra.unit().dot(rb.unit()))))
+		velocity = rotateAbout(velocity, ra.cross(rb).unit(), (frac-0.5)*swept)
+	}
+
+	// Interpolate along the chord, then restore the interpolated radius so the
+	// point stays on the orbit instead of cutting under it.
+	radius := ra.norm() + (rb.norm()-ra.norm())*frac
+	r := ra.add(rb.sub(ra).scale(frac)).unit().scale(radius)
+
+	nearest := a
+	if frac > 0.5 {
+		nearest = b
+	}
+
+	lat, lon, alt := ecefToGeodetic(r)
+
+	return &orbitalState{
+		Timestamp:  timestamp,
+		R:          r,
+		V:          velocity,
+		Sun:        subpointDirection(solarSubpoint(time.Unix(timestamp, 0).UTC())),
+		Latitude:   lat,
+		Longitude:  lon,
+		Altitude:   alt,
+		Visibility: nearest.Visibility,
+	}
+}
+
+// lvlhMatrix returns the rotation from ECEF to the nominal ISS body frame,
+// which in +XVV flight coincides with LVLH: Z towards nadir, Y opposite the
+// orbital angular momentum and X completing the triad along the velocity.
+func lvlhMatrix(r, inertialVelocity vec3) mat3 {
+	z := r.scale(-1).unit()
+	y := r.cross(inertialVelocity).scale(-1).unit()
+	x := y.cross(z)
+
+	return mat3{x, y, z}
+}
+
+// GetAttitude returns the nominal LVLH attitude at timestamp (0 means now).
+// The quaternion rotates body-frame vectors into ECEF, which is the
+// convention of a three.js object.quaternion in an Earth-fixed scene.
+func (s *ISSService) GetAttitude(timestamp int64) (*models.AttitudeResponse, error) {
+	if timestamp == 0 {
+		timestamp = s.Now().Unix()
+	}
+
+	state, err := s.stateAt(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ISS state: %w", err)
+	}
+
+	vInertial := state.inertialVelocity()
+	ecefToBody := lvlhMatrix(state.R, vInertial)
+
+	return &models.AttitudeResponse{
+		Timestamp:      timestamp,
+		Mode:           ATTITUDE_MODE,
+		ReferenceFrame: ATTITUDE_FRAME,
+		Quaternion:     quaternionFromMatrix(ecefToBody.transpose()),
+		NadirBody:      ecefToBody.mulVec(state.R.scale(-1).unit()).toModel(),
+		VelocityBody:   ecefToBody.mulVec(vInertial.unit()).toModel(),
+		SunBody:        ecefToBody.mulVec(state.Sun).toModel(),
+		Latitude:       state.Latitude,
+		Longitude:      state.Longitude,
+		Altitude:       state.Altitude,
+		Visibility:     state.Visibility,
+	}, nil
+}
+
+// GetGimbalAngles estimates the nominal sun-tracking joint angles at
+// timestamp (0 means now). Conventions, all rotations about body axes:
+//
+//   - SARJ alpha rotates the arrays about +Y; at 0° the array faces zenith
+//     (-Z) and it increases as the sun moves from zenith towards -X.
+//   - BGA beta tilts the array normal towards +Y to follow the sun out of
+//     the orbital plane.
+//   - TRRJ keeps the radiators edge-on to the sun. Of the two edge-on angles
+//     it picks the one within the joint's ±115° travel.
+func (s *ISSService) GetGimbalAngles(timestamp int64) (*models.GimbalAnglesResponse, error) {
+	attitude, err := s.GetAttitude(timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	sun := vec3{attitude.SunBody.X, attitude.SunBody.Y, attitude.SunBody.Z}
+	alpha, beta, trrj := gimbalAngles(sun)
+
+	return &models.GimbalAnglesResponse{
+		Timestamp: attitude.Timestamp,
+		SARJAlpha: alpha,
+		BGABeta:   beta,
+		TRRJ:      trrj,
+		// The orbit normal is -Y in LVLH, so the solar beta angle is the
+		// sun's elevation above the orbital plane towards -Y.
+		SolarBetaAngle: -beta,
+		SunBody:        attitude.SunBody,
+		Visibility:     attitude.Visibility,
+	}, nil
+}
+
+func gimbalAngles(sunBody vec3) (alpha, beta, trrj float64) {
+	alpha = normalizeDegrees(toDegrees(math.Atan2(-sunBody[0], -sunBody[2])), 360)
+	beta = toDegrees(math.Asin(math.Max(-1, math.Min(1, sunBody[1]))))
+
+	trrj = normalizeDegrees(alpha+90, 180)
+	if trrj > TRRJ_LIMIT {
+		trrj -= 180
+	} else if trrj < -TRRJ_LIMIT {
+		trrj += 180
+	}
+
+	return alpha, beta, trrj
+}
+
+// normalizeDegrees wraps angle into [0, 360) when span is 360, or into
+// [-180, 180) when span is 180.
+func normalizeDegrees(angle, span float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle < 0 {
+		angle += 360
+	}
+	if span == 180 && angle >= 180 {
+		angle -= 360
+	}
+	return angle
+}
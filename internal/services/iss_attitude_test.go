@@ -0,0 +1,93 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestGeodeticRoundTrip(t *testing.T) {
+	cases := [][3]float64{
+		{0, 0, 420},
+		{51.6, 19.9, 415.3},
+		{-51.6, -170.2, 430.1},
+		{89.9, 45, 400},
+	}
+
+	for _, c := range cases {
+		lat, lon, alt := ecefToGeodetic(geodeticToECEF(c[0], c[1], c[2]))
+		if math.Abs(lat-c[0]) > 1e-6 || math.Abs(lon-c[1]) > 1e-6 || math.Abs(alt-c[2]) > 1e-3 {
+			t.Errorf("round trip of %v gave (%f, %f, %f)", c, lat, lon, alt)
+		}
+	}
+}
+
+func TestQuaternionFromMatrix(t *testing.T) {
+	// 90° rotation about Z: X -> Y.
+	m := mat3{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}}
+	q := quaternionFromMatrix(m)
+
+	half := math.Sqrt(0.5)
+	if math.Abs(q.W-half) > 1e-9 || math.Abs(q.Z-half) > 1e-9 || math.Abs(q.X) > 1e-9 || math.Abs(q.Y) > 1e-9 {
+		t.Errorf("expected (%f, 0, 0, %f), got %+v", half, half, q)
+	}
+}
+
+func TestInterpolatedAttitude(t *testing.T) {
+	a := &models.ISSPosition{Latitude: 10, Longitude: 20, Altitude: 420, Timestamp: 1000, SolarLat: 5, SolarLon: 20, Visibility: "daylight"}
+	b := &models.ISSPosition{Latitude: 10.4, Longitude: 20.5, Altitude: 420.1, Timestamp: 1010, SolarLat: 5, SolarLon: 19.96, Visibility: "daylight"}
+
+	state := interpolateState(a, b, 1005)
+	if state.Latitude <= a.Latitude || state.Latitude >= b.Latitude {
+		t.Fatalf("interpolated latitude %f not between samples", state.Latitude)
+	}
+
+	speed := state.inertialVelocity().norm()
+	if speed < 5 || speed > 10 {
+		t.Fatalf("implausible inertial speed %f km/s", speed)
+	}
+
+	c := lvlhMatrix(state.R, state.inertialVelocity())
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if got := vec3(c[i]).dot(vec3(c[j])); math.Abs(got-want) > 1e-9 {
+				t.Fatalf("LVLH axes not orthonormal: row %d . row %d = %f", i, j, got)
+			}
+		}
+	}
+
+	nadir := c.mulVec(state.R.scale(-1).unit())
+	if math.Abs(nadir[2]-1) > 1e-9 {
+		t.Errorf("nadir should map to +Z, got %v", nadir)
+	}
+
+	velocity := c.mulVec(state.inertialVelocity().unit())
+	if velocity[0] < 0.99 || math.Abs(velocity[1]) > 1e-9 {
+		t.Errorf("velocity should lie along +X, got %v", velocity)
+	}
+}
+
+func TestGimbalAngles(t *testing.T) {
+	cases := []struct {
+		sun               vec3
+		alpha, beta, trrj float64
+	}{
+		{sun: vec3{0, 0, -1}, alpha: 0, beta: 0, trrj: 90},
+		{sun: vec3{-1, 0, 0}, alpha: 90, beta: 0, trrj: 0},
+		{sun: vec3{0, 0, 1}, alpha: 180, beta: 0, trrj: -90},
+		{sun: vec3{0, math.Sin(toRadians(30)), -math.Cos(toRadians(30))}, alpha: 0, beta: 30, trrj: 90},
+	}
+
+	for _, c := range cases {
+		alpha, beta, trrj := gimbalAngles(c.sun)
+		if math.Abs(alpha-c.alpha) > 1e-9 || math.Abs(beta-c.beta) > 1e-9 || math.Abs(trrj-c.trrj) > 1e-9 {
+			t.Errorf("sun %v: got alpha=%f beta=%f trrj=%f, want %f %f %f",
+				c.sun, alpha, beta, trrj, c.alpha, c.beta, c.trrj)
+		}
+	}
+}
@@ -3,10 +3,12 @@ package services
 import (
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"math"
-	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"iss-model-backend/internal/models"
@@ -14,37 +16,121 @@ import (
 	"gorm.io/gorm"
 )
 
+// BASE_URL is the wheretheiss.at API, overridable with ISS_API_URL.
+var BASE_URL = upstreamURL("ISS_API_URL", "https://api.wheretheiss.at/v1")
+
 const (
-	BASE_URL             = "https://api.wheretheiss.at/v1"
-	ISS_ID               = 25544
-	DATA_RETENTION_HOURS = 8
-	COLLECTION_INTERVAL  = 10 * time.Second
-	API_TIMEOUT          = 30 * time.Second
+	ISS_ID              = 25544
+	COLLECTION_INTERVAL = 10 * time.Second
+	API_TIMEOUT         = 30 * time.Second
+	KM_TO_MILES         = 0.621371
+	MILES_TO_KM         = 1.60934
+
+	// UPSTREAM_POSITION_TTL is how long API positions at a fixed timestamp
+	// are cached.
+	UPSTREAM_POSITION_TTL = time.Hour
 )
 
-type ISSService struct {
-	db *gorm.DB
+// DATA_RETENTION_HOURS is how long collected positions are kept. Raise it
+// with ISS_DATA_RETENTION_HOURS to keep a longer archive for replays.
+var DATA_RETENTION_HOURS = retentionHoursFromEnv()
+
+func retentionHoursFromEnv() int {
+	if hours, err := strconv.Atoi(os.Getenv("ISS_DATA_RETENTION_HOURS")); err == nil && hours > 0 {
+		return hours
+	}
+	return 8
 }
 
-func NewISSService(db *gorm.DB) *ISSService {
-	service := &ISSService{db: db}
+// PositionHook is called by the collector for every newly stored position.
+// prev is the previously collected position, or nil right after startup.
+type PositionHook func(prev, curr *models.ISSPosition)
 
-	go service.startDataCollection()
+// ISSService tracks one satellite: the ISS, unless it is a ForSatellite
+// view.
+type ISSService struct {
+	db          *gorm.DB
+	hub         *EventHub
+	upstream    *UpstreamClient
+	clock       Clock
+	satelliteID int
+
+	hooksMu       sync.RWMutex
+	positionHooks []PositionHook
+	// collectMu serialises collections, which overlap briefly when a
+	// satellite's schedule changes.
+	collectMu     sync.Mutex
+	lastCollected *models.ISSPosition
+	// previousCollected is the accepted sample before lastCollected, which
+	// together seed the propagation check of the next one. After a restart
+	// both are loaded from the last stored positions.
+	previousCollected *models.ISSPosition
+	// collected is set once this process has stored a sample; until then
+	// lastCollected comes from the database and hooks get no prev.
+	collected bool
+	// rejected is the latest run of quarantined samples that agree with
+	// each other, see extendRejectedRun.
+	rejected []*models.ISSPosition
+}
+
+// NewISSService returns the ISS service. Its collection is scheduled by the
+// SatelliteService along with the rest of the catalog.
+func NewISSService(db *gorm.DB, hub *EventHub, upstream *UpstreamClient) *ISSService {
+	service := &ISSService{db: db, hub: hub, upstream: upstream, clock: RealClock, satelliteID: ISS_ID}
+	service.positionHooks = []PositionHook{service.detectOrbitEvents}
 
 	go service.startCleanupRoutine()
 
 	return service
 }
 
+// WithClock returns a view of the service that reads time from clock, for
+// simulations. The view shares the database but does not collect data or
+// run position hooks.
+func (s *ISSService) WithClock(clock Clock) *ISSService {
+	return &ISSService{db: s.db, hub: s.hub, upstream: s.upstream, clock: clock, satelliteID: s.satelliteID}
+}
+
+// ForSatellite returns a view of the service tracking another satellite. The
+// view has no position hooks and publishes no live events, which are about
+// the ISS, and skips the ISS-specific sample validation.
+func (s *ISSService) ForSatellite(noradID int) *ISSService {
+	return &ISSService{db: s.db, upstream: s.upstream, clock: s.clock, satelliteID: noradID}
+}
+
+// SatelliteID returns the NORAD catalog number of the tracked satellite.
+func (s *ISSService) SatelliteID() int {
+	return s.satelliteID
+}
+
+// positions scopes a query to the tracked satellite's positions.
+func (s *ISSService) positions() *gorm.DB {
+	return s.db.Where("satellite_id = ?", s.satelliteID)
+}
+
+// Now returns the current time as seen by the service's clock.
+func (s *ISSService) Now() time.Time {
+	return s.clock.Now()
+}
+
+// Simulated reports whether the service reads a simulation clock.
+func (s *ISSService) Simulated() bool {
+	return s.clock != RealClock
+}
+
 func (s *ISSService) GetCurrentPosition(units string) (*models.ISSPosition, error) {
 	if units == "" {
 		units = "kilometers"
 	}
 
+	if s.Simulated() {
+		return s.positionAt(s.Now().Unix(), units)
+	}
+
 	var recentPos models.ISSPosition
-	cutoff := time.Now().Add(-30 * time.Second).Unix()
+	cutoff := s.Now().Add(-30 * time.Second).Unix()
 
-	err := s.db.Where("timestamp >= ?", cutoff).
+	err := s.positions().Where("timestamp >= ?", cutoff).
 		Order("timestamp desc").
 		First(&recentPos).Error
 
@@ -58,7 +144,7 @@ func (s *ISSService) GetCurrentPosition(units string) (*models.ISSPosition, erro
 		return nil, fmt.Errorf("failed to fetch current position: %w", err)
 	}
 
-	s.db.Where("timestamp = ?", position.Timestamp).FirstOrCreate(position)
+	s.positions().Where("timestamp = ?", position.Timestamp).FirstOrCreate(position)
 
 	return position, nil
 }
@@ -68,17 +154,17 @@ func (s *ISSService) GetHistoricalPosition(timestamp int64, units string) (*mode
 		units = "kilometers"
 	}
 
-	now := time.Now().Unix()
+	now := s.Now().Unix()
 	if timestamp < now-4*3600 || timestamp > now+4*3600 {
 		return nil, fmt.Errorf("timestamp outside retention window (4 hours back/forward)")
 	}
 
 	var position models.ISSPosition
-	err := s.db.Raw("SELECT * FROM positions WHERE timestamp BETWEEN ? AND ? ORDER BY ABS(timestamp - ?) LIMIT 1",
-		timestamp-60, timestamp+60, timestamp).
-		Scan(&position).Error
+	result := s.db.Raw("SELECT * FROM iss_positions WHERE satellite_id = ? AND timestamp BETWEEN ? AND ? ORDER BY ABS(timestamp - ?) LIMIT 1",
+		s.satelliteID, timestamp-60, timestamp+60, timestamp).
+		Scan(&position)
 
-	if err == nil {
+	if result.Error == nil && result.RowsAffected > 0 {
 		s.convertUnits(&position, units)
 		return &position, nil
 	}
@@ -88,7 +174,7 @@ func (s *ISSService) GetHistoricalPosition(timestamp int64, units string) (*mode
 		return nil, fmt.Errorf("failed to fetch historical position: %w", err)
 	}
 
-	s.db.Where("timestamp = ?", apiPosition.Timestamp).FirstOrCreate(apiPosition)
+	s.positions().Where("timestamp = ?", apiPosition.Timestamp).FirstOrCreate(apiPosition)
 
 	return apiPosition, nil
 }
@@ -99,7 +185,7 @@ func (s *ISSService) GetPositionsInRange(startTime, endTime int64, units string)
 	}
 
 	var positions []*models.ISSPosition
-	err := s.db.Where("timestamp BETWEEN ? AND ?", startTime, endTime).
+	err := s.positions().Where("timestamp BETWEEN ? AND ?", startTime, endTime).
 		Order("timestamp asc").
 		Find(&positions).Error
 	if err != nil {
@@ -113,29 +199,66 @@ func (s *ISSService) GetPositionsInRange(startTime, endTime int64, units string)
 	return positions, nil
 }
 
+// GetPositionsAfter returns up to limit stored positions with after <
+// timestamp <= end, oldest first. Replays page through the archive with it.
+func (s *ISSService) GetPositionsAfter(after, end int64, limit int) ([]*models.ISSPosition, error) {
+	var positions []*models.ISSPosition
+	err := s.positions().Where("timestamp > ? AND timestamp <= ?", after, end).
+		Order("timestamp asc").
+		Limit(limit).
+		Find(&positions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// positionAt returns the stored position nearest to timestamp, or the API's
+// position for that instant. API results are not stored: simulated time can
+// lie far outside the collection window.
+func (s *ISSService) positionAt(timestamp int64, units string) (*models.ISSPosition, error) {
+	var position models.ISSPosition
+	result := s.db.Raw("SELECT * FROM iss_positions WHERE satellite_id = ? AND timestamp BETWEEN ? AND ? ORDER BY ABS(timestamp - ?) LIMIT 1",
+		s.satelliteID, timestamp-SIM_MATCH_WINDOW, timestamp+SIM_MATCH_WINDOW, timestamp).
+		Scan(&position)
+
+	if result.Error == nil && result.RowsAffected > 0 {
+		s.convertUnits(&position, units)
+		return &position, nil
+	}
+
+	apiPosition, err := s.fetchFromAPI(timestamp, units)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch simulated position: %w", err)
+	}
+
+	return apiPosition, nil
+}
+
 func (s *ISSService) GetStatistics() (map[string]any, error) {
 	stats := make(map[string]any)
 
 	var totalCount int64
-	if err := s.db.Model(&models.ISSPosition{}).Count(&totalCount).Error; err != nil {
+	if err := s.positions().Model(&models.ISSPosition{}).Count(&totalCount).Error; err != nil {
 		return nil, err
 	}
 	stats["total_positions"] = totalCount
 
 	var latest models.ISSPosition
-	if err := s.db.Order("timestamp desc").First(&latest).Error; err == nil {
+	if err := s.positions().Order("timestamp desc").First(&latest).Error; err == nil {
 		stats["latest_position"] = latest
 		stats["latest_timestamp"] = time.Unix(latest.Timestamp, 0).Format(time.RFC3339)
 	}
 
 	var oldest models.ISSPosition
-	if err := s.db.Order("timestamp asc").First(&oldest).Error; err == nil {
+	if err := s.positions().Order("timestamp asc").First(&oldest).Error; err == nil {
 		stats["oldest_timestamp"] = time.Unix(oldest.Timestamp, 0).Format(time.RFC3339)
 	}
 
 	var recentCount int64
-	oneHourAgo := time.Now().Add(-time.Hour).Unix()
-	if err := s.db.Model(&models.ISSPosition{}).Where("timestamp >= ?", oneHourAgo).Count(&recentCount).Error; err == nil {
+	oneHourAgo := s.Now().Add(-time.Hour).Unix()
+	if err := s.positions().Model(&models.ISSPosition{}).Where("timestamp >= ?", oneHourAgo).Count(&recentCount).Error; err == nil {
 		stats["positions_last_hour"] = recentCount
 	}
 
@@ -185,7 +308,7 @@ func (s *ISSService) calculateSunAzimuth(issLat, issLon, sunLat, sunLon float64)
 }
 
 func (s *ISSService) fetchFromAPI(timestamp int64, units string) (*models.ISSPosition, error) {
-	url := fmt.Sprintf("%s/satellites/%d", BASE_URL, ISS_ID)
+	url := fmt.Sprintf("%s/satellites/%d", BASE_URL, s.satelliteID)
 
 	if timestamp > 0 {
 		url += fmt.Sprintf("?timestamp=%d", timestamp)
@@ -196,32 +319,52 @@ func (s *ISSService) fetchFromAPI(timestamp int64, units string) (*models.ISSPos
 		url += fmt.Sprintf("?units=%s", units)
 	}
 
-	client := &http.Client{Timeout: API_TIMEOUT}
+	// A position at a given instant never changes; the current one does.
+	ttl := time.Duration(0)
+	if timestamp > 0 {
+		ttl = UPSTREAM_POSITION_TTL
+	}
 
-	resp, err := client.Get(url)
+	body, err := s.upstream.Get(url, ttl)
 	if err != nil {
 		return nil, fmt.Errorf("API request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed: %s - %s", resp.Status, string(body))
+	var apiResponse models.ISSPositionResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	position := apiResponse.ToISSPosition()
+
+	return position, nil
+}
+
+// fetchPositionsFromAPI returns the positions in kilometers at up to
+// UPSTREAM_BATCH_SIZE timestamps with a single request.
+func (s *ISSService) fetchPositionsFromAPI(timestamps []int64) ([]*models.ISSPosition, error) {
+	values := make([]string, len(timestamps))
+	for i, ts := range timestamps {
+		values[i] = strconv.FormatInt(ts, 10)
+	}
+	url := fmt.Sprintf("%s/satellites/%d/positions?timestamps=%s&units=kilometers", BASE_URL, s.satelliteID, strings.Join(values, ","))
+
+	body, err := s.upstream.Get(url, UPSTREAM_POSITION_TTL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("API request failed: %w", err)
 	}
 
-	var apiResponse models.ISSPositionResponse
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
+	var apiResponses []models.ISSPositionResponse
+	if err := json.Unmarshal(body, &apiResponses); err != nil {
 		return nil, fmt.Errorf("failed to parse API response: %w", err)
 	}
 
-	position := apiResponse.ToISSPosition()
+	positions := make([]*models.ISSPosition, len(apiResponses))
+	for i := range apiResponses {
+		positions[i] = apiResponses[i].ToISSPosition()
+	}
 
-	return position, nil
+	return positions, nil
 }
 
 func (s *ISSService) convertUnits(position *models.ISSPosition, targetUnits string) {
@@ -229,9 +372,6 @@ func (s *ISSService) convertUnits(position *models.ISSPosition, targetUnits stri
 		return
 	}
 
-	const KM_TO_MILES = 0.621371
-	const MILES_TO_KM = 1.60934
-
 	if position.Units == "kilometers" && targetUnits == "miles" {
 		position.Altitude *= KM_TO_MILES
 		position.Velocity *= KM_TO_MILES
@@ -245,40 +385,190 @@ func (s *ISSService) convertUnits(position *models.ISSPosition, targetUnits stri
 	}
 }
 
-func (s *ISSService) startDataCollection() {
-	log.Println("Starting ISS data collection...")
+func (s *ISSService) collectData() {
+	s.collectMu.Lock()
+	defer s.collectMu.Unlock()
 
-	ticker := time.NewTicker(COLLECTION_INTERVAL)
-	defer ticker.Stop()
+	position, err := s.fetchFromAPI(0, "kilometers")
+	if err != nil {
+		log.Printf("Failed to fetch position of satellite %d: %v", s.satelliteID, err)
+		return
+	}
 
-	s.collectData()
+	// The plausibility bounds are the ISS's own.
+	if s.satelliteID == ISS_ID {
+		s.seedHistory()
+
+		history := []*models.ISSPosition{s.previousCollected, s.lastCollected}
+		if violation := checkPosition(position, history, s.Now()); violation != nil {
+			s.rejected = extendRejectedRun(s.rejected, position, s.Now())
+			if len(s.rejected) < VALIDATION_RESYNC_SAMPLES {
+				s.quarantine(position, violation)
+				return
+			}
+
+			// The earlier samples of the run stay quarantined for review.
+			log.Printf("Accepting ISS position at timestamp=%d: %d consecutive samples agree with each other, replacing the reference (%s)",
+				position.Timestamp, len(s.rejected), violation.reason)
+			s.lastCollected = s.rejected[len(s.rejected)-2]
+		}
+		s.rejected = nil
+	}
 
-	for range ticker.C {
-		s.collectData()
+	var existingPos models.ISSPosition
+	result := s.positions().Where("timestamp = ?", position.Timestamp).FirstOrCreate(&existingPos, position)
+
+	if result.Error != nil {
+		log.Printf("Failed to store position of satellite %d: %v", s.satelliteID, result.Error)
+		return
+	}
+
+	if result.RowsAffected > 0 {
+		log.Printf("Stored new position of satellite %d: lat=%.4f, lon=%.4f, timestamp=%d",
+			s.satelliteID, position.Latitude, position.Longitude, position.Timestamp)
+
+		prev := s.lastCollected
+		s.previousCollected, s.lastCollected = prev, position
+		if !s.collected {
+			prev = nil
+		}
+		s.collected = true
+
+		if s.hub != nil {
+			s.hub.Publish(models.LiveEventPosition, position)
+		}
+		s.notifyPositionHooks(prev, position)
 	}
 }
 
-func (s *ISSService) collectData() {
-	position, err := s.fetchFromAPI(0, "kilometers")
-	if err != nil {
-		log.Printf("Failed to fetch ISS position: %v", err)
+// seedHistory loads the last two stored positions as the reference for the
+// first samples collected after a restart.
+func (s *ISSService) seedHistory() {
+	if s.collected || s.lastCollected != nil {
 		return
 	}
 
-	var existingPos models.ISSPosition
-	result := s.db.Where("timestamp = ?", position.Timestamp).FirstOrCreate(&existingPos, position)
+	var stored []*models.ISSPosition
+	if err := s.positions().Order("timestamp desc").Limit(2).Find(&stored).Error; err != nil {
+		log.Printf("Failed to load the last positions of satellite %d: %v", s.satelliteID, err)
+		return
+	}
+	for _, position := range stored {
+		s.convertUnits(position, "kilometers")
+	}
 
+	switch len(stored) {
+	case 2:
+		s.previousCollected = stored[1]
+		fallthrough
+	case 1:
+		s.lastCollected = stored[0]
+	}
+}
+
+// quarantine sets an implausible sample aside for review instead of storing
+// it. Upstream tends to repeat a bad sample, so it is only recorded once.
+func (s *ISSService) quarantine(position *models.ISSPosition, violation *positionViolation) {
+	sample := &models.QuarantinedPosition{
+		SatelliteID: position.SatelliteID,
+		Name:        position.Name,
+		Latitude:    position.Latitude,
+		Longitude:   position.Longitude,
+		Altitude:    position.Altitude,
+		Velocity:    position.Velocity,
+		Visibility:  position.Visibility,
+		Footprint:   position.Footprint,
+		Timestamp:   position.Timestamp,
+		Daynum:      position.Daynum,
+		SolarLat:    position.SolarLat,
+		SolarLon:    position.SolarLon,
+		Units:       position.Units,
+		Rule:        violation.rule,
+		Reason:      violation.reason,
+		Status:      models.QuarantineStatusPending,
+	}
+
+	result := s.db.Where("satellite_id = ? AND timestamp = ? AND rule = ?", sample.SatelliteID, sample.Timestamp, sample.Rule).FirstOrCreate(sample)
 	if result.Error != nil {
-		log.Printf("Failed to store ISS position: %v", result.Error)
+		log.Printf("Failed to quarantine ISS position: %v", result.Error)
 		return
 	}
-
 	if result.RowsAffected > 0 {
-		log.Printf("Stored new ISS position: lat=%.4f, lon=%.4f, timestamp=%d",
-			position.Latitude, position.Longitude, position.Timestamp)
+		log.Printf("Quarantined ISS position at timestamp=%d: %s", position.Timestamp, violation.reason)
+	}
+}
+
+// OnNewPosition registers a hook that runs after each newly collected position.
+func (s *ISSService) OnNewPosition(hook PositionHook) {
+	s.hooksMu.Lock()
+	defer s.hooksMu.Unlock()
+
+	s.positionHooks = append(s.positionHooks, hook)
+}
+
+func (s *ISSService) notifyPositionHooks(prev, curr *models.ISSPosition) {
+	s.hooksMu.RLock()
+	hooks := make([]PositionHook, len(s.positionHooks))
+	copy(hooks, s.positionHooks)
+	s.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(prev, curr)
+	}
+}
+
+// detectOrbitEvents publishes eclipse transitions, equator crossings and SAA
+// crossings between two consecutive collected positions.
+func (s *ISSService) detectOrbitEvents(prev, curr *models.ISSPosition) {
+	for _, event := range OrbitEventsBetween(prev, curr) {
+		s.hub.Publish(models.LiveEventOrbit, event)
 	}
 }
 
+// OrbitEventsBetween returns the eclipse transitions, equator crossings and
+// SAA crossings between two consecutive positions. prev may be nil.
+func OrbitEventsBetween(prev, curr *models.ISSPosition) []*models.OrbitEvent {
+	if prev == nil {
+		return nil
+	}
+
+	var types []string
+
+	if prev.Visibility != curr.Visibility {
+		switch curr.Visibility {
+		case "eclipsed":
+			types = append(types, models.OrbitEventEclipseEntry)
+		case "daylight":
+			types = append(types, models.OrbitEventEclipseExit)
+		}
+	}
+
+	if prev.Latitude < 0 && curr.Latitude >= 0 {
+		types = append(types, models.OrbitEventAscendingNode)
+	} else if prev.Latitude >= 0 && curr.Latitude < 0 {
+		types = append(types, models.OrbitEventDescendingNode)
+	}
+
+	wasInSAA, inSAA := InSAA(prev.Latitude, prev.Longitude), InSAA(curr.Latitude, curr.Longitude)
+	if !wasInSAA && inSAA {
+		types = append(types, models.OrbitEventSAAEntry)
+	} else if wasInSAA && !inSAA {
+		types = append(types, models.OrbitEventSAAExit)
+	}
+
+	events := make([]*models.OrbitEvent, len(types))
+	for i, eventType := range types {
+		events[i] = &models.OrbitEvent{
+			EventType: eventType,
+			Latitude:  curr.Latitude,
+			Longitude: curr.Longitude,
+			Timestamp: curr.Timestamp,
+		}
+	}
+
+	return events
+}
+
 func (s *ISSService) startCleanupRoutine() {
 	log.Println("Starting ISS data cleanup routine...")
 
@@ -291,7 +581,7 @@ func (s *ISSService) startCleanupRoutine() {
 }
 
 func (s *ISSService) cleanupOldData() {
-	cutoff := time.Now().Add(-DATA_RETENTION_HOURS * time.Hour).Unix()
+	cutoff := s.Now().Add(-time.Duration(DATA_RETENTION_HOURS) * time.Hour).Unix()
 
 	result := s.db.Where("timestamp < ?", cutoff).Delete(&models.ISSPosition{})
 
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	MISSION_EVENT_DEFAULT_LIMIT = 100
+	MISSION_EVENT_MAX_LIMIT     = 1000
+)
+
+var ErrInvalidMissionEvent = errors.New("invalid mission event")
+
+// MissionEventFilter narrows the mission timeline. Zero values don't filter.
+// From and To select events overlapping that span; an event without an end
+// covers only its start. Offset skips events for paging.
+type MissionEventFilter struct {
+	Type      string
+	From      int64
+	To        int64
+	Crew      string
+	ModuleID  uint
+	VehicleID uint
+	Limit     int
+	Offset    int
+}
+
+type MissionEventService struct {
+	db *gorm.DB
+}
+
+func NewMissionEventService(db *gorm.DB) *MissionEventService {
+	return &MissionEventService{db: db}
+}
+
+// CreateEvent stores an event and links it to the modules and vehicles with
+// the given IDs.
+func (s *MissionEventService) CreateEvent(event *models.MissionEvent, moduleIDs, vehicleIDs []uint) (*models.MissionEvent, error) {
+	if err := s.validateEvent(event, moduleIDs, vehicleIDs); err != nil {
+		return nil, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Modules", "Vehicles").Create(event).Error; err != nil {
+			return err
+		}
+		return replaceRelated(tx, event, moduleIDs, vehicleIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEventByID(event.ID)
+}
+
+func (s *MissionEventService) GetEventByID(id uint) (*models.MissionEvent, error) {
+	var event models.MissionEvent
+	if err := s.db.Preload("Modules").Preload("Vehicles").First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetEvents lists the timeline in chronological order, ties broken by ID so
+// pages don't overlap.
+func (s *MissionEventService) GetEvents(filter MissionEventFilter) ([]models.MissionEvent, error) {
+	query := s.db.Preload("Modules").Preload("Vehicles")
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.From != 0 {
+		query = query.Where("COALESCE(end_at, start_at) >= ?", time.Unix(filter.From, 0))
+	}
+	if filter.To != 0 {
+		query = query.Where("start_at <= ?", time.Unix(filter.To, 0))
+	}
+	if filter.Crew != "" {
+		crew, err := json.Marshal([]string{filter.Crew})
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("crew @> ?", string(crew))
+	}
+	if filter.ModuleID != 0 {
+		query = query.Where("id IN (?)",
+			s.db.Table("mission_event_modules").Select("mission_event_id").Where("module_id = ?", filter.ModuleID))
+	}
+	if filter.VehicleID != 0 {
+		query = query.Where("id IN (?)",
+			s.db.Table("mission_event_vehicles").Select("mission_event_id").Where("visiting_vehicle_id = ?", filter.VehicleID))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > MISSION_EVENT_MAX_LIMIT {
+		limit = MISSION_EVENT_DEFAULT_LIMIT
+	}
+
+	var events []models.MissionEvent
+	if err := query.Order("start_at asc, id asc").Limit(limit).Offset(filter.Offset).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *MissionEventService) UpdateEvent(id uint, update *models.MissionEvent, moduleIDs, vehicleIDs []uint) (*models.MissionEvent, error) {
+	event, err := s.GetEventByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateEvent(update, moduleIDs, vehicleIDs); err != nil {
+		return nil, err
+	}
+
+	event.Type = update.Type
+	event.Title = update.Title
+	event.Description = update.Description
+	event.StartAt = update.StartAt
+	event.EndAt = update.EndAt
+	event.Crew = update.Crew
+	event.DeltaVMs = update.DeltaVMs
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Modules", "Vehicles").Save(event).Error; err != nil {
+			return err
+		}
+		return replaceRelated(tx, event, moduleIDs, vehicleIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetEventByID(id)
+}
+
+func (s *MissionEventService) DeleteEvent(id uint) error {
+	if err := s.db.Delete(&models.MissionEvent{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *MissionEventService) validateEvent(event *models.MissionEvent, moduleIDs, vehicleIDs []uint) error {
+	if !slices.Contains(models.MissionEventTypes, event.Type) {
+		return fmt.Errorf("%w: type must be one of %v", ErrInvalidMissionEvent, models.MissionEventTypes)
+	}
+	if event.Title == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidMissionEvent)
+	}
+	if event.StartAt.IsZero() {
+		return fmt.Errorf("%w: start_at is required", ErrInvalidMissionEvent)
+	}
+	if event.EndAt != nil && event.EndAt.Before(event.StartAt) {
+		return fmt.Errorf("%w: end_at must not be before start_at", ErrInvalidMissionEvent)
+	}
+	if event.DeltaVMs != nil && event.Type != models.MissionEventReboost {
+		return fmt.Errorf("%w: delta_v_ms only applies to reboosts", ErrInvalidMissionEvent)
+	}
+
+	if err := requireExisting(s.db, &models.Module{}, "module", moduleIDs); err != nil {
+		return err
+	}
+	return requireExisting(s.db, &models.VisitingVehicle{}, "vehicle", vehicleIDs)
+}
+
+// requireExisting checks that every ID names a row of model's table.
+func requireExisting(db *gorm.DB, model any, name string, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var found []uint
+	if err := db.Model(model).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !slices.Contains(found, id) {
+			return fmt.Errorf("%w: %s %d does not exist", ErrInvalidMissionEvent, name, id)
+		}
+	}
+	return nil
+}
+
+// replaceRelated links the event to exactly the given modules and vehicles.
+// The rows are loaded in full because GORM upserts associated records.
+func replaceRelated(tx *gorm.DB, event *models.MissionEvent, moduleIDs, vehicleIDs []uint) error {
+	modules := []models.Module{}
+	if len(moduleIDs) > 0 {
+		if err := tx.Find(&modules, moduleIDs).Error; err != nil {
+			return err
+		}
+	}
+	vehicles := []models.VisitingVehicle{}
+	if len(vehicleIDs) > 0 {
+		if err := tx.Find(&vehicles, vehicleIDs).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Model(event).Association("Modules").Replace(modules); err != nil {
+		return err
+	}
+	return tx.Model(event).Association("Vehicles").Replace(vehicles)
+}
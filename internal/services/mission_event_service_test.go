@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB starts a Postgres container for filters that only Postgres can
+// run, such as jsonb containment. It skips the test without Docker.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	container, err := tcpostgres.Run(ctx, "postgres:latest",
+		tcpostgres.WithDatabase("database"),
+		tcpostgres.WithUsername("user"),
+		tcpostgres.WithPassword("password"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(30*time.Second)),
+	)
+	if err != nil {
+		t.Fatalf("could not start postgres container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Errorf("could not terminate postgres container: %v", err)
+		}
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		t.Fatalf("connection string: %v", err)
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("could not connect to postgres: %v", err)
+	}
+	if err := db.AutoMigrate(&models.Module{}, &models.VisitingVehicle{}, &models.MissionEvent{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
+
+func TestGetMissionEvents(t *testing.T) {
+	db := newTestDB(t)
+	service := NewMissionEventService(db)
+
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 12, 0, 0, 0, time.UTC) }
+	until := func(d int) *time.Time { end := day(d); return &end }
+
+	harmony := models.Module{Name: "Harmony"}
+	unity := models.Module{Name: "Unity"}
+	for _, module := range []*models.Module{&harmony, &unity} {
+		if err := db.Create(module).Error; err != nil {
+			t.Fatalf("create module: %v", err)
+		}
+	}
+	dragon := models.VisitingVehicle{Name: "Crew-10", VehicleType: "crew", ArrivalAt: day(1), ModuleID: harmony.ID, Port: "zenith"}
+	if err := db.Create(&dragon).Error; err != nil {
+		t.Fatalf("create vehicle: %v", err)
+	}
+
+	create := func(event models.MissionEvent, moduleIDs, vehicleIDs []uint) uint {
+		t.Helper()
+		created, err := service.CreateEvent(&event, moduleIDs, vehicleIDs)
+		if err != nil {
+			t.Fatalf("create %q: %v", event.Title, err)
+		}
+		return created.ID
+	}
+
+	docking := create(models.MissionEvent{Type: models.MissionEventDocking, Title: "Crew-10 docks", StartAt: day(2)},
+		[]uint{harmony.ID}, []uint{dragon.ID})
+	eva := create(models.MissionEvent{Type: models.MissionEventEVA, Title: "US EVA 92", StartAt: day(5), EndAt: until(5),
+		Crew: models.StringArray{"Anne McClain", "Nichole Ayers"}}, []uint{unity.ID}, nil)
+	experiment := create(models.MissionEvent{Type: models.MissionEventExperiment, Title: "Plant growth", StartAt: day(3), EndAt: until(20),
+		Crew: models.StringArray{"Nichole Ayers"}}, nil, nil)
+	reboost := create(models.MissionEvent{Type: models.MissionEventReboost, Title: "Reboost", StartAt: day(10)}, nil, []uint{dragon.ID})
+
+	tests := []struct {
+		name   string
+		filter MissionEventFilter
+		want   []uint
+	}{
+		{"everything in order", MissionEventFilter{}, []uint{docking, experiment, eva, reboost}},
+		{"type", MissionEventFilter{Type: models.MissionEventEVA}, []uint{eva}},
+		{"crew member", MissionEventFilter{Crew: "Nichole Ayers"}, []uint{experiment, eva}},
+		{"crew needs an exact name", MissionEventFilter{Crew: "Ayers"}, nil},
+		{"module", MissionEventFilter{ModuleID: harmony.ID}, []uint{docking}},
+		{"vehicle", MissionEventFilter{VehicleID: dragon.ID}, []uint{docking, reboost}},
+		// The experiment started before the window but is still running.
+		{"overlapping a window", MissionEventFilter{From: day(6).Unix(), To: day(9).Unix()}, []uint{experiment}},
+		{"event without an end at its start", MissionEventFilter{From: day(10).Unix(), To: day(10).Unix()}, []uint{experiment, reboost}},
+		{"event without an end before the window", MissionEventFilter{From: day(2).Add(time.Hour).Unix(), To: day(2).Add(2 * time.Hour).Unix()}, nil},
+		{"combined", MissionEventFilter{Crew: "Nichole Ayers", From: day(6).Unix()}, []uint{experiment}},
+		{"page", MissionEventFilter{Limit: 2, Offset: 1}, []uint{experiment, eva}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := service.GetEvents(tt.filter)
+			if err != nil {
+				t.Fatalf("GetEvents: %v", err)
+			}
+
+			var got []uint
+			for _, event := range events {
+				got = append(got, event.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetEvents(%+v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidModule   = errors.New("invalid module")
+	ErrDuplicateModule = errors.New("duplicate module")
+)
+
+type ModuleService struct {
+	db *gorm.DB
+}
+
+func NewModuleService(db *gorm.DB) *ModuleService {
+	return &ModuleService{db: db}
+}
+
+func (s *ModuleService) CreateModule(module *models.Module) (*models.Module, error) {
+	if err := validateModule(module); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(module.Name, 0); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(module).Error; err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+func (s *ModuleService) GetModuleByID(id uint) (*models.Module, error) {
+	var module models.Module
+	if err := s.db.First(&module, id).Error; err != nil {
+		return nil, err
+	}
+	return &module, nil
+}
+
+func (s *ModuleService) GetAllModules() ([]models.Module, error) {
+	var modules []models.Module
+	if err := s.db.Order("launch_date asc nulls last, name asc").Find(&modules).Error; err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+func (s *ModuleService) UpdateModule(id uint, update *models.Module) (*models.Module, error) {
+	module, err := s.GetModuleByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateModule(update); err != nil {
+		return nil, err
+	}
+	if err := s.checkNameAvailable(update.Name, id); err != nil {
+		return nil, err
+	}
+
+	module.Name = update.Name
+	module.Description = update.Description
+	module.LaunchDate = update.LaunchDate
+	module.MassKg = update.MassKg
+	module.Dimensions = update.Dimensions
+	module.Agency = update.Agency
+	module.AttachmentPort = update.AttachmentPort
+	module.DockingPorts = update.DockingPorts
+	module.Transform = update.Transform
+	module.ModelAssetURL = update.ModelAssetURL
+
+	if err := s.db.Save(module).Error; err != nil {
+		return nil, err
+	}
+	return module, nil
+}
+
+func (s *ModuleService) DeleteModule(id uint) error {
+	if err := s.db.Delete(&models.Module{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkNameAvailable reports whether a module other than id already uses
+// name. Deleted modules don't count.
+func (s *ModuleService) checkNameAvailable(name string, id uint) error {
+	var count int64
+	if err := s.db.Model(&models.Module{}).Where("name = ? AND id <> ?", name, id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: a module named %q already exists", ErrDuplicateModule, name)
+	}
+	return nil
+}
+
+func validateModule(module *models.Module) error {
+	if module.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidModule)
+	}
+	if module.MassKg < 0 {
+		return fmt.Errorf("%w: mass_kg must not be negative", ErrInvalidModule)
+	}
+
+	dims := module.Dimensions
+	if dims.LengthM < 0 || dims.WidthM < 0 || dims.HeightM < 0 {
+		return fmt.Errorf("%w: dimensions must not be negative", ErrInvalidModule)
+	}
+
+	if module.DockingPorts == nil {
+		module.DockingPorts = models.StringArray{}
+	}
+
+	if module.Transform.Scale == 0 {
+		module.Transform.Scale = 1
+	}
+
+	// An all-zero rotation is an omitted one; store the identity instead.
+	rotation := module.Transform.Rotation
+	if rotation == (models.Quaternion{}) {
+		module.Transform.Rotation = models.Quaternion{W: 1}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	MQTT_DEFAULT_CLIENT_ID = "iss-model-backend"
+	MQTT_DEFAULT_PREFIX    = "iss"
+	MQTT_PUBLISH_TIMEOUT   = 5 * time.Second
+)
+
+// MQTTConfig is read from the environment. The publisher is disabled when
+// BrokerURL is empty.
+type MQTTConfig struct {
+	BrokerURL   string
+	ClientID    string
+	Username    string
+	Password    string
+	TopicPrefix string
+	QoS         byte
+}
+
+func MQTTConfigFromEnv() MQTTConfig {
+	config := MQTTConfig{
+		BrokerURL:   os.Getenv("MQTT_BROKER_URL"),
+		ClientID:    os.Getenv("MQTT_CLIENT_ID"),
+		Username:    os.Getenv("MQTT_USERNAME"),
+		Password:    os.Getenv("MQTT_PASSWORD"),
+		TopicPrefix: os.Getenv("MQTT_TOPIC_PREFIX"),
+	}
+
+	if config.ClientID == "" {
+		config.ClientID = MQTT_DEFAULT_CLIENT_ID
+	}
+	if config.TopicPrefix == "" {
+		config.TopicPrefix = MQTT_DEFAULT_PREFIX
+	}
+	if qos, err := strconv.Atoi(os.Getenv("MQTT_QOS")); err == nil && qos >= 0 && qos <= 2 {
+		config.QoS = byte(qos)
+	}
+
+	return config
+}
+
+// MQTTPublisher mirrors the ISS hub events to an MQTT broker:
+//
+//	<prefix>/position         every collected position
+//	<prefix>/position/latest  the latest position, retained
+//	<prefix>/events/orbit     eclipse, equator crossing and SAA entry/exit events
+//	<prefix>/events/geofence  geofence enter and exit events
+//	<prefix>/status           "online" / "offline", retained (last will)
+//
+// It authenticates with MQTT_USERNAME and MQTT_PASSWORD when they are set.
+// It consumes its own hub subscription, so a slow or unreachable broker only
+// drops MQTT messages and never blocks the collector.
+type MQTTPublisher struct {
+	config MQTTConfig
+	client mqtt.Client
+	hub    *EventHub
+}
+
+func NewMQTTPublisher(config MQTTConfig, hub *EventHub) *MQTTPublisher {
+	statusTopic := config.TopicPrefix + "/status"
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(config.BrokerURL).
+		SetClientID(config.ClientID).
+		SetUsername(config.Username).
+		SetPassword(config.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(10*time.Second).
+		SetMaxReconnectInterval(time.Minute).
+		SetWill(statusTopic, "offline", config.QoS, true).
+		SetOnConnectHandler(func(client mqtt.Client) {
+			log.Printf("Connected to MQTT broker %s", config.BrokerURL)
+			client.Publish(statusTopic, config.QoS, true, "online")
+		}).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			log.Printf("Lost connection to MQTT broker: %v", err)
+		})
+
+	publisher := &MQTTPublisher{
+		config: config,
+		client: mqtt.NewClient(opts),
+		hub:    hub,
+	}
+
+	// With ConnectRetry enabled the client keeps retrying in the background,
+	// so the token only reports the first attempt.
+	publisher.client.Connect()
+
+	go publisher.startPublishing()
+
+	return publisher
+}
+
+func (p *MQTTPublisher) startPublishing() {
+	log.Println("Starting MQTT publisher...")
+
+	events, _ := p.hub.Subscribe()
+
+	for event := range events {
+		switch event.Type {
+		case models.LiveEventPosition:
+			p.publish("position", false, event.Data)
+			p.publish("position/latest", true, event.Data)
+		case models.LiveEventOrbit:
+			p.publish("events/orbit", false, event.Data)
+		case models.LiveEventGeofence:
+			p.publish("events/geofence", false, event.Data)
+		}
+	}
+}
+
+func (p *MQTTPublisher) publish(topic string, retained bool, data any) {
+	if !p.client.IsConnectionOpen() {
+		return
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("Failed to encode MQTT payload: %v", err)
+		return
+	}
+
+	fullTopic := fmt.Sprintf("%s/%s", p.config.TopicPrefix, topic)
+	token := p.client.Publish(fullTopic, p.config.QoS, retained, payload)
+
+	if !token.WaitTimeout(MQTT_PUBLISH_TIMEOUT) {
+		log.Printf("MQTT publish to %s timed out", fullTopic)
+		return
+	}
+	if err := token.Error(); err != nil {
+		log.Printf("MQTT publish to %s failed: %v", fullTopic, err)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	OVERFLIGHT_DAY_FORMAT   = "2006-01-02"
+	OVERFLIGHT_MAX_GAP      = 60 // seconds; longer collector gaps are not attributed
+	OVERFLIGHT_DEFAULT_DAYS = 30
+	OVERFLIGHT_MAX_DAYS     = 366
+)
+
+var (
+	ErrInvalidOverflightQuery = errors.New("invalid overflight query")
+	ErrUnknownRegion          = errors.New("unknown region")
+)
+
+type OverflightService struct {
+	db *gorm.DB
+}
+
+func NewOverflightService(db *gorm.DB) *OverflightService {
+	return &OverflightService{db: db}
+}
+
+// Accumulate is registered as a collector hook. The interval between two
+// positions is split evenly between the regions (and UTC days) below each
+// end, so a border crossing is credited to both sides.
+func (s *OverflightService) Accumulate(prev, curr *models.ISSPosition) {
+	if prev == nil {
+		return
+	}
+
+	gap := curr.Timestamp - prev.Timestamp
+	if gap <= 0 || gap > OVERFLIGHT_MAX_GAP {
+		return
+	}
+
+	half := float64(gap) / 2
+	rows := make(map[string]*models.OverflightDay)
+	for _, position := range []*models.ISSPosition{prev, curr} {
+		region := ReverseGeocode(position.Latitude, position.Longitude)
+		day := time.Unix(position.Timestamp, 0).UTC().Format(OVERFLIGHT_DAY_FORMAT)
+
+		key := day + "/" + region.Code
+		if row, ok := rows[key]; ok {
+			row.Seconds += half
+			continue
+		}
+		rows[key] = &models.OverflightDay{
+			Day:        day,
+			RegionCode: region.Code,
+			RegionName: region.Name,
+			RegionType: region.Type,
+			Seconds:    half,
+		}
+	}
+
+	for _, row := range rows {
+		err := s.db.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "day"}, {Name: "region_code"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"seconds":    gorm.Expr("overflight_days.seconds + EXCLUDED.seconds"),
+				"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+			}),
+		}).Create(row).Error
+		if err != nil {
+			log.Printf("Failed to store overflight time for %s: %v", row.RegionCode, err)
+		}
+	}
+}
+
+// GetRanking returns regions ranked by time overflown in the period (today,
+// the last 7 days or all time, in UTC days). regionType filters the list but
+// percentages are always of the period total.
+func (s *OverflightService) GetRanking(period, regionType string, limit int) (*models.OverflightRankingResponse, error) {
+	today := time.Now().UTC()
+
+	query := s.db.Model(&models.OverflightDay{})
+	response := &models.OverflightRankingResponse{
+		Period:  period,
+		To:      today.Format(OVERFLIGHT_DAY_FORMAT),
+		Regions: []models.OverflightTotal{},
+	}
+
+	switch period {
+	case models.OverflightPeriodDay:
+		response.From = response.To
+	case models.OverflightPeriodWeek:
+		response.From = today.AddDate(0, 0, -6).Format(OVERFLIGHT_DAY_FORMAT)
+	case models.OverflightPeriodAll:
+	default:
+		return nil, fmt.Errorf("%w: period must be day, week or all", ErrInvalidOverflightQuery)
+	}
+	if response.From != "" {
+		query = query.Where("day >= ?", response.From)
+	}
+
+	switch regionType {
+	case "", models.RegionTypeCountry, models.RegionTypeOcean, models.RegionTypeSea:
+	default:
+		return nil, fmt.Errorf("%w: type must be country, ocean or sea", ErrInvalidOverflightQuery)
+	}
+
+	var totals []struct {
+		RegionCode string
+		RegionName string
+		RegionType string
+		Seconds    float64
+	}
+	err := query.
+		Select("region_code, MAX(region_name) AS region_name, MAX(region_type) AS region_type, SUM(seconds) AS seconds").
+		Group("region_code").
+		Order("seconds desc").
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, total := range totals {
+		response.TotalSeconds += total.Seconds
+	}
+
+	for _, total := range totals {
+		if regionType != "" && total.RegionType != regionType {
+			continue
+		}
+		if limit > 0 && len(response.Regions) >= limit {
+			break
+		}
+		response.Regions = append(response.Regions, models.OverflightTotal{
+			Rank:    len(response.Regions) + 1,
+			Region:  models.Region{Code: total.RegionCode, Name: total.RegionName, Type: total.RegionType},
+			Seconds: total.Seconds,
+			Hours:   math.Round(total.Seconds/36) / 100,
+			Percent: math.Round(total.Seconds/response.TotalSeconds*10000) / 100,
+		})
+	}
+
+	return response, nil
+}
+
+// GetSeries returns the daily overflight time of one region over the last
+// days UTC days, ending today. Country codes are matched case-insensitively.
+func (s *OverflightService) GetSeries(code string, days int) (*models.OverflightSeriesResponse, error) {
+	if days <= 0 || days > OVERFLIGHT_MAX_DAYS {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidOverflightQuery, OVERFLIGHT_MAX_DAYS)
+	}
+
+	if len(code) == 2 {
+		code = strings.ToUpper(code)
+	} else {
+		code = strings.ToLower(code)
+	}
+
+	var latest models.OverflightDay
+	if err := s.db.Where("region_code = ?", code).Order("day desc").First(&latest).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: no overflights recorded for %q", ErrUnknownRegion, code)
+		}
+		return nil, err
+	}
+
+	today := time.Now().UTC()
+	from := today.AddDate(0, 0, -(days - 1)).Format(OVERFLIGHT_DAY_FORMAT)
+
+	var rows []models.OverflightDay
+	if err := s.db.Where("region_code = ? AND day >= ?", code, from).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		byDay[row.Day] = row.Seconds
+	}
+
+	response := &models.OverflightSeriesResponse{
+		Region: models.Region{Code: latest.RegionCode, Name: latest.RegionName, Type: latest.RegionType},
+		Days:   make([]models.OverflightDayValue, 0, days),
+	}
+	for i := days - 1; i >= 0; i-- {
+		day := today.AddDate(0, 0, -i).Format(OVERFLIGHT_DAY_FORMAT)
+		response.Days = append(response.Days, models.OverflightDayValue{Day: day, Seconds: byDay[day]})
+		response.TotalSeconds += byDay[day]
+	}
+
+	return response, nil
+}
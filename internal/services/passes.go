@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	PASS_DEFAULT_HOURS         = 24
+	PASS_MAX_HOURS             = 72
+	PASS_DEFAULT_MIN_ELEVATION = 10.0
+	// PASS_LOOKBACK is longer than any pass, so propagating from this far
+	// back finds the AOS of a pass that is already in progress.
+	PASS_LOOKBACK = 15 * time.Minute
+	// PASS_MATCH_SECONDS is how far a requested AOS may be from a predicted
+	// one: predictions seeded at different times differ by a few seconds.
+	PASS_MATCH_SECONDS = 60
+)
+
+var ErrUnknownPass = errors.New("unknown pass")
+
+// lookAngles returns the azimuth and elevation in degrees and the range in
+// kilometers of target as seen from site, an ECEF position at geodetic
+// latitude lat and longitude lon.
+func lookAngles(lat, lon float64, site, target vec3) (azimuth, elevation, rangeKm float64) {
+	latRad, lonRad := toRadians(lat), toRadians(lon)
+	east := vec3{-math.Sin(lonRad), math.Cos(lonRad), 0}
+	north := vec3{-math.Sin(latRad) * math.Cos(lonRad), -math.Sin(latRad) * math.Sin(lonRad), math.Cos(latRad)}
+	up := subpointDirection(lat, lon)
+
+	rho := target.sub(site)
+	rangeKm = rho.norm()
+	elevation = toDegrees(math.Asin(rho.dot(up) / rangeKm))
+	azimuth = normalizeDegrees(toDegrees(math.Atan2(rho.dot(east), rho.dot(north))), 360)
+	return azimuth, elevation, rangeKm
+}
+
+// inSunlight reports whether an ECEF position is outside the Earth's shadow,
+// modelled as a cylinder. The penumbra the model ignores takes the ISS only
+// a few seconds to cross.
+func inSunlight(r, sun vec3) bool {
+	d := r.dot(sun)
+	return d > 0 || r.sub(sun.scale(d)).norm() > EARTH_RADIUS_KM
+}
+
+// GetUpcomingPasses predicts the passes over an observer in the next hours
+// by propagating the current state.
+func (s *ISSService) GetUpcomingPasses(observer models.Observer, hours int, minElevation float64) (*models.ObserverPassesResponse, error) {
+	position, err := s.GetCurrentPosition("kilometers")
+	if err != nil {
+		return nil, err
+	}
+
+	passes, err := s.PredictObserverPasses(observer, position.Timestamp, time.Duration(hours)*time.Hour, minElevation)
+	if err != nil {
+		return nil, err
+	}
+	for i := range passes {
+		passes[i].SkyPlotURL = passSkyPlotURL(observer, passes[i].AOS.Timestamp)
+	}
+
+	return &models.ObserverPassesResponse{
+		Observer:     observer,
+		From:         position.Timestamp,
+		Hours:        hours,
+		MinElevation: minElevation,
+		Passes:       passes,
+	}, nil
+}
+
+// PredictObserverPasses propagates the orbit and lists the passes above the
+// observer's horizon that end after from and start before from+horizon,
+// keeping those that climb to at least minElevation. Points are sampled on
+// the propagation step; AOS and LOS are interpolated to the horizon.
+func (s *ISSService) PredictObserverPasses(observer models.Observer, from int64, horizon time.Duration, minElevation float64) ([]models.ObserverPass, error) {
+	p, err := s.seedPropagator(from - int64(PASS_LOOKBACK.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+
+	return observerPasses(p, observer, from, time.Unix(from, 0).Add(horizon), minElevation), nil
+}
+
+// observerPasses lists the passes in the track up to end. A pass still above
+// the horizon at end is followed to its LOS.
+func observerPasses(p *propagator, observer models.Observer, from int64, end time.Time, minElevation float64) []models.ObserverPass {
+	lat, lon := observer.Latitude, observer.Longitude
+	site := geodeticToECEF(lat, lon, observer.AltitudeM/1000)
+	up := subpointDirection(lat, lon)
+
+	passes := []models.ObserverPass{}
+	var path []models.SkyPoint
+	var prev *models.SkyPoint
+	visible := false
+
+	visit := func(point *models.ISSPosition) {
+		r := geodeticToECEF(point.Latitude, point.Longitude, point.Altitude)
+		sun := subpointDirection(solarSubpoint(time.Unix(point.Timestamp, 0).UTC()))
+		azimuth, elevation, rangeKm := lookAngles(lat, lon, site, r)
+
+		sample := models.SkyPoint{
+			Timestamp: point.Timestamp,
+			Azimuth:   azimuth,
+			Elevation: elevation,
+			RangeKm:   rangeKm,
+			Sunlit:    inSunlight(r, sun),
+		}
+
+		switch {
+		case elevation >= 0:
+			if path == nil && prev != nil {
+				path = append(path, horizonCrossing(*prev, sample))
+			}
+			path = append(path, sample)
+
+			observerSunElevation := toDegrees(math.Asin(up.dot(sun)))
+			if sample.Sunlit && observerSunElevation <= SUN_ELEVATION_CIVIL {
+				visible = true
+			}
+		case path != nil:
+			path = append(path, horizonCrossing(path[len(path)-1], sample))
+			pass := newObserverPass(path, visible)
+			if pass.LOS.Timestamp >= from && pass.TCA.Elevation >= minElevation {
+				passes = append(passes, pass)
+			}
+			path, visible = nil, false
+		}
+
+		prev = &sample
+	}
+
+	for _, point := range p.track(end) {
+		visit(point)
+	}
+
+	// No pass lasts as long as PASS_LOOKBACK, so this always reaches the LOS.
+	if path != nil {
+		for _, point := range p.track(end.Add(PASS_LOOKBACK)) {
+			visit(point)
+			if path == nil {
+				break
+			}
+		}
+	}
+
+	return passes
+}
+
+// horizonCrossing interpolates the point between two samples where the ISS
+// rises above or sets below the horizon.
+func horizonCrossing(a, b models.SkyPoint) models.SkyPoint {
+	f := a.Elevation / (a.Elevation - b.Elevation)
+
+	crossing := models.SkyPoint{
+		Timestamp: a.Timestamp + int64(math.Round(f*float64(b.Timestamp-a.Timestamp))),
+		Azimuth:   normalizeDegrees(a.Azimuth+f*normalizeDegrees(b.Azimuth-a.Azimuth, 180), 360),
+		RangeKm:   a.RangeKm + f*(b.RangeKm-a.RangeKm),
+		Sunlit:    a.Sunlit,
+	}
+	if f > 0.5 {
+		crossing.Sunlit = b.Sunlit
+	}
+	return crossing
+}
+
+func newObserverPass(path []models.SkyPoint, visible bool) models.ObserverPass {
+	tca := 0
+	for i := range path {
+		path[i].Azimuth = math.Round(path[i].Azimuth*10) / 10
+		path[i].Elevation = math.Round(path[i].Elevation*10) / 10
+		path[i].RangeKm = math.Round(path[i].RangeKm*10) / 10
+		if path[i].Elevation > path[tca].Elevation {
+			tca = i
+		}
+	}
+
+	aos, los := path[0], path[len(path)-1]
+	return models.ObserverPass{
+		AOS:             aos,
+		TCA:             path[tca],
+		LOS:             los,
+		DurationSeconds: los.Timestamp - aos.Timestamp,
+		Visible:         visible,
+		Path:            path,
+	}
+}
+
+// passSkyPlotURL is the path of a pass's sky plot, relative to the API root.
+func passSkyPlotURL(observer models.Observer, aos int64) string {
+	return fmt.Sprintf("/iss/passes/%d/sky.svg?lat=%g&lon=%g&alt=%g",
+		aos, observer.Latitude, observer.Longitude, observer.AltitudeM)
+}
+
+// GetPassSkyPlot finds the pass over the observer that starts at aos (as
+// listed by GetUpcomingPasses) and draws its sky plot as SVG.
+func (s *ISSService) GetPassSkyPlot(observer models.Observer, aos int64) (string, error) {
+	now := s.Now().Unix()
+	if aos > now+PASS_MAX_HOURS*3600 {
+		return "", fmt.Errorf("%w: passes are predicted up to %d hours ahead", ErrUnknownPass, PASS_MAX_HOURS)
+	}
+
+	from := min(now, aos)
+	passes, err := s.PredictObserverPasses(observer, from, time.Duration(aos-from)*time.Second+PASS_LOOKBACK, 0)
+	if err != nil {
+		return "", err
+	}
+
+	for _, pass := range passes {
+		if math.Abs(float64(pass.AOS.Timestamp-aos)) <= PASS_MATCH_SECONDS {
+			return renderSkyPlot(&pass), nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: no pass starts at %d", ErrUnknownPass, aos)
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/xml"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestLookAngles(t *testing.T) {
+	site := geodeticToECEF(50, 20, 0)
+
+	_, elevation, rangeKm := lookAngles(50, 20, site, geodeticToECEF(50, 20, 420))
+	if math.Abs(elevation-90) > 0.01 || math.Abs(rangeKm-420) > 0.01 {
+		t.Errorf("overhead: elevation %f, range %f, want 90 and 420", elevation, rangeKm)
+	}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		azimuth  float64
+	}{
+		{"north", 55, 20, 0},
+		{"east", 50, 26, 90},
+		{"south", 45, 20, 180},
+		{"west", 50, 14, 270},
+	}
+	for _, tt := range tests {
+		azimuth, elevation, _ := lookAngles(50, 20, site, geodeticToECEF(tt.lat, tt.lon, 420))
+		if math.Abs(normalizeDegrees(azimuth-tt.azimuth, 180)) > 3 {
+			t.Errorf("%s: azimuth %f, want about %f", tt.name, azimuth, tt.azimuth)
+		}
+		if elevation <= 0 || elevation >= 90 {
+			t.Errorf("%s: elevation %f, want above the horizon", tt.name, elevation)
+		}
+	}
+}
+
+func TestInSunlight(t *testing.T) {
+	sun := vec3{1, 0, 0}
+	if !inSunlight(vec3{-6800, 6500, 0}, sun) {
+		t.Error("point beside the shadow cylinder reported eclipsed")
+	}
+	if inSunlight(vec3{-6800, 0, 100}, sun) {
+		t.Error("point behind the Earth reported sunlit")
+	}
+}
+
+func TestRenderSkyPlot(t *testing.T) {
+	var path []models.SkyPoint
+	for i := 0; i <= 60; i++ {
+		path = append(path, models.SkyPoint{
+			Timestamp: 1760000000 + int64(i*10),
+			Azimuth:   300 + float64(i)*2,
+			Elevation: 60 * math.Sin(math.Pi*float64(i)/60),
+			Sunlit:    i < 40,
+		})
+	}
+	pass := newObserverPass(path, true)
+
+	svg := renderSkyPlot(&pass)
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("invalid SVG: %v", err)
+		}
+	}
+
+	for _, want := range []string{"AOS 08:53:20", "TCA 08:58:20 60°", "LOS 09:03:20", "stroke-dasharray=\"5 4\""} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("sky plot is missing %q", want)
+		}
+	}
+}
+
+func TestObserverPassesFollowPassAtEnd(t *testing.T) {
+	orbit := func() *propagator {
+		radius := WGS84_A_KM + 420
+		speed := math.Sqrt(EARTH_MU / radius)
+		inclination := toRadians(51.6)
+		return &propagator{
+			t: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			r: vec3{radius, 0, 0},
+			v: vec3{0, speed * math.Cos(inclination), speed * math.Sin(inclination)},
+		}
+	}
+	observer := models.Observer{Latitude: 40.7, Longitude: -74.0}
+
+	start := orbit().t
+	passes := observerPasses(orbit(), observer, start.Unix(), start.Add(24*time.Hour), 0)
+	if len(passes) == 0 {
+		t.Fatal("no passes in a day")
+	}
+
+	first := passes[0]
+	end := time.Unix(first.AOS.Timestamp, 0).Add(time.Minute)
+	cut := observerPasses(orbit(), observer, start.Unix(), end, 0)
+	if len(cut) != 1 || cut[0].AOS != first.AOS || cut[0].TCA != first.TCA || cut[0].LOS != first.LOS {
+		t.Errorf("passes with the end inside the first one = %+v, want %+v", cut, first)
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	LANDMARK_DEFAULT_HOURS     = 12
+	LANDMARK_MAX_HOURS         = 24
+	LANDMARK_DEFAULT_RADIUS_KM = 150.0
+	LANDMARK_MAX_RADIUS_KM     = 1000.0
+)
+
+var ErrUnknownLandmark = errors.New("unknown landmark")
+
+// citiesCSV holds cities of more than 100,000 inhabitants in the GeoNames
+// column order we need (name, country_code, country, latitude, longitude,
+// population), grouped by country. It is a curated subset of about 700
+// capitals and major cities with rounded populations, compiled by hand
+// rather than generated, to keep the binary small. Countries with no city
+// above the threshold, such as Malta or Eswatini, have no entry;
+// data/country_places.csv covers them for reverse geocoding.
+//
+//go:embed data/cities.csv
+var citiesCSV []byte
+
+//go:embed data/landmarks.csv
+var landmarksCSV []byte
+
+type city struct {
+	name        string
+	countryCode string
+	country     string
+	lat, lon    float64
+	population  int64
+}
+
+var (
+	cities      = mustLoadCities()
+	citiesIndex = newSpatialIndex(len(cities), func(i int) (float64, float64) {
+		return cities[i].lat, cities[i].lon
+	})
+
+	landmarks     = mustLoadLandmarks()
+	landmarkIndex = newSpatialIndex(len(landmarks), func(i int) (float64, float64) {
+		return landmarks[i].Latitude, landmarks[i].Longitude
+	})
+)
+
+// readEmbeddedCSV returns the rows of an embedded CSV file without its
+// header, checking the column count.
+func readEmbeddedCSV(name string, data []byte, columns int) [][]string {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = columns
+
+	rows, err := reader.ReadAll()
+	if err != nil || len(rows) < 2 {
+		panic(fmt.Sprintf("invalid embedded %s: %v", name, err))
+	}
+	return rows[1:]
+}
+
+func mustParseCoordinates(name string, row []string, latCol int) (float64, float64) {
+	lat, errLat := strconv.ParseFloat(row[latCol], 64)
+	lon, errLon := strconv.ParseFloat(row[latCol+1], 64)
+	if errLat != nil || errLon != nil {
+		panic(fmt.Sprintf("invalid coordinates in embedded %s: %v", name, row))
+	}
+	return lat, lon
+}
+
+func mustLoadCities() []city {
+	rows := readEmbeddedCSV("cities.csv", citiesCSV, 6)
+
+	result := make([]city, len(rows))
+	for i, row := range rows {
+		lat, lon := mustParseCoordinates("cities.csv", row, 3)
+		population, _ := strconv.ParseInt(row[5], 10, 64)
+		result[i] = city{
+			name:        row[0],
+			countryCode: row[1],
+			country:     row[2],
+			lat:         lat,
+			lon:         lon,
+			population:  population,
+		}
+	}
+	return result
+}
+
+func mustLoadLandmarks() []models.Landmark {
+	rows := readEmbeddedCSV("landmarks.csv", landmarksCSV, 6)
+
+	result := make([]models.Landmark, len(rows))
+	for i, row := range rows {
+		lat, lon := mustParseCoordinates("landmarks.csv", row, 4)
+		result[i] = models.Landmark{
+			ID:          row[0],
+			Name:        row[1],
+			Kind:        row[2],
+			CountryCode: row[3],
+			Latitude:    lat,
+			Longitude:   lon,
+		}
+	}
+	return result
+}
+
+// NearestCity returns the embedded city closest to lat/lon.
+func NearestCity(lat, lon float64) *models.NearbyPlace {
+	i := citiesIndex.nearest(lat, lon)
+	if i < 0 {
+		return nil
+	}
+
+	c := cities[i]
+	return &models.NearbyPlace{
+		Name:        c.name,
+		CountryCode: c.countryCode,
+		Country:     c.country,
+		Latitude:    c.lat,
+		Longitude:   c.lon,
+		Population:  c.population,
+		DistanceKm:  math.Round(haversineKm(lat, lon, c.lat, c.lon)*10) / 10,
+		BearingDeg:  math.Round(initialBearing(lat, lon, c.lat, c.lon)*10) / 10,
+	}
+}
+
+// Landmarks lists the embedded landmarks.
+func Landmarks() []models.Landmark {
+	return landmarks
+}
+
+// GetUpcomingLandmarkOverflights predicts landmark passes in the next hours
+// by propagating the current state.
+func (s *ISSService) GetUpcomingLandmarkOverflights(hours int, ids []string, radiusKm float64) (*models.LandmarkOverflightsResponse, error) {
+	position, err := s.GetCurrentPosition("kilometers")
+	if err != nil {
+		return nil, err
+	}
+
+	overflights, err := s.PredictLandmarkOverflights(position.Timestamp, time.Duration(hours)*time.Hour, ids, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LandmarkOverflightsResponse{
+		From:        position.Timestamp,
+		Hours:       hours,
+		RadiusKm:    radiusKm,
+		Overflights: overflights,
+	}, nil
+}
+
+// PredictLandmarkOverflights propagates the orbit from from to from+horizon
+// and lists every pass within radiusKm of the given landmarks, ordered by
+// closest approach. An empty ids slice selects all landmarks.
+func (s *ISSService) PredictLandmarkOverflights(from int64, horizon time.Duration, ids []string, radiusKm float64) ([]models.LandmarkOverflight, error) {
+	selected, err := selectLandmarks(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.seedPropagator(from)
+	if err != nil {
+		return nil, err
+	}
+
+	overflights := []models.LandmarkOverflight{}
+	open := make(map[int]*models.LandmarkOverflight)
+
+	for _, point := range p.track(time.Unix(from, 0).Add(horizon)) {
+		seen := make(map[int]bool)
+
+		landmarkIndex.within(point.Latitude, point.Longitude, radiusKm, func(i int) {
+			if selected != nil && !selected[i] {
+				return
+			}
+			seen[i] = true
+
+			l := landmarks[i]
+			distance := haversineKm(point.Latitude, point.Longitude, l.Latitude, l.Longitude)
+
+			pass, ok := open[i]
+			if !ok {
+				pass = &models.LandmarkOverflight{Landmark: l, StartTime: point.Timestamp, MinDistanceKm: math.Inf(1)}
+				open[i] = pass
+			}
+			pass.EndTime = point.Timestamp
+			if distance < pass.MinDistanceKm {
+				pass.ClosestApproachTime = point.Timestamp
+				pass.MinDistanceKm = math.Round(distance*10) / 10
+				pass.ISSLatitude = point.Latitude
+				pass.ISSLongitude = point.Longitude
+				pass.BearingDeg = math.Round(initialBearing(point.Latitude, point.Longitude, l.Latitude, l.Longitude)*10) / 10
+			}
+		})
+
+		for i, pass := range open {
+			if !seen[i] {
+				overflights = append(overflights, *pass)
+				delete(open, i)
+			}
+		}
+	}
+
+	for _, pass := range open {
+		overflights = append(overflights, *pass)
+	}
+
+	sort.Slice(overflights, func(i, j int) bool {
+		return overflights[i].ClosestApproachTime < overflights[j].ClosestApproachTime
+	})
+
+	return overflights, nil
+}
+
+// selectLandmarks maps landmark ids to indexes. It returns nil when ids is
+// empty, meaning every landmark.
+func selectLandmarks(ids []string) (map[int]bool, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	byID := make(map[string]int, len(landmarks))
+	for i, l := range landmarks {
+		byID[l.ID] = i
+	}
+
+	selected := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		i, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownLandmark, id)
+		}
+		selected[i] = true
+	}
+	return selected, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSpatialIndexMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for range 500 {
+		lat := math.Asin(2*rng.Float64()-1) * 180 / math.Pi
+		lon := rng.Float64()*360 - 180
+
+		best, bestDist := -1, math.Inf(1)
+		for i, c := range cities {
+			if d := haversineKm(lat, lon, c.lat, c.lon); d < bestDist {
+				best, bestDist = i, d
+			}
+		}
+		if got := citiesIndex.nearest(lat, lon); got != best {
+			t.Fatalf("nearest(%f, %f) = %s, want %s", lat, lon, cities[got].name, cities[best].name)
+		}
+
+		var within, want int
+		citiesIndex.within(lat, lon, 800, func(int) { within++ })
+		for _, c := range cities {
+			if haversineKm(lat, lon, c.lat, c.lon) <= 800 {
+				want++
+			}
+		}
+		if within != want {
+			t.Fatalf("within(%f, %f, 800) found %d cities, want %d", lat, lon, within, want)
+		}
+	}
+}
+
+func TestNearestCity(t *testing.T) {
+	// North-east of Cairo, then just across the antimeridian from Wellington.
+	place := NearestCity(30.1, 31.4)
+	if place.Name != "Cairo" || place.DistanceKm > 20 {
+		t.Errorf("got %s at %.1f km, want Cairo", place.Name, place.DistanceKm)
+	}
+	if place.BearingDeg < 180 || place.BearingDeg > 270 {
+		t.Errorf("bearing to Cairo %.1f, want south-west", place.BearingDeg)
+	}
+
+	place = NearestCity(-41.3, -179.0)
+	if place.Name != "Wellington" {
+		t.Errorf("got %s, want Wellington across the antimeridian", place.Name)
+	}
+}
+
+func TestReverseGeocode(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		code     string
+	}{
+		{52.0, 20.0, "PL"},   // central Poland
+		{-25.0, 125.0, "AU"}, // Gibson Desert, far from any city
+		{35.0, 18.0, "mediterranean_sea"},
+		{43.0, 34.0, "black_sea"},
+		{0.0, -30.0, "atlantic_ocean"},
+		{-20.0, 80.0, "indian_ocean"},
+		{0.0, -150.0, "pacific_ocean"},
+		{15.0, 88.0, "indian_ocean"}, // Bay of Bengal
+
+		// Open water a few hundred kilometers off a coast.
+		{35.7, 144.5, "pacific_ocean"},  // east of Honshu
+		{55.0, 5.0, "atlantic_ocean"},   // North Sea
+		{0.0, 5.0, "atlantic_ocean"},    // Gulf of Guinea
+		{40.0, -68.0, "atlantic_ocean"}, // off Cape Cod
+		{-30.0, 157.0, "pacific_ocean"}, // Tasman Sea
+		{-35.0, 115.0, "indian_ocean"},  // off Cape Leeuwin
+
+		// Land the outlines leave out, next to a city.
+		{21.3, -157.9, "US"}, // Oahu
+		{1.35, 103.8, "SG"},
+
+		// Countries without a city above 100,000 inhabitants.
+		{-26.5, 31.5, "SZ"},
+		{72.0, -40.0, "GL"}, // central ice sheet
+		{17.2, -88.7, "BZ"},
+		{4.8, 114.8, "BN"},
+		{35.9, 14.4, "MT"},
+		{10.4, -61.3, "TT"},
+		{-4.65, 55.45, "SC"},
+		{43.93, 12.45, "SM"},
+		{65.0, -17.0, "IS"}, // north-east Iceland, not Greenland
+		{22.0, 2.0, "DZ"},   // Sahara, near the border with Mali
+	}
+
+	for _, tt := range tests {
+		if got := ReverseGeocode(tt.lat, tt.lon); got.Code != tt.code {
+			t.Errorf("ReverseGeocode(%v, %v) = %s, want %s", tt.lat, tt.lon, got.Code, tt.code)
+		}
+	}
+}
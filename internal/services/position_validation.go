@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+// Bounds a collected sample must respect. They are wide enough for reboosts
+// and the altitude swing over an orbit, so anything outside them is an
+// upstream glitch rather than the ISS doing something unusual.
+const (
+	VALIDATION_MIN_ALTITUDE_KM = 300
+	VALIDATION_MAX_ALTITUDE_KM = 500
+	VALIDATION_MAX_LATITUDE    = 53 // inclination 51.64° plus margin
+	VALIDATION_MIN_VELOCITY    = 25000.0
+	VALIDATION_MAX_VELOCITY    = 30000.0 // km/h, as reported upstream
+	// Speed over the ground implied by two samples, from the chord between
+	// their ECEF positions. The ISS moves at 7.2-7.7 km/s relative to the
+	// rotating Earth.
+	VALIDATION_MIN_SPEED_KMS = 6.8
+	VALIDATION_MAX_SPEED_KMS = 8.2
+	// The altitude changes by at most ~0.02 km/s over an orbit.
+	VALIDATION_MAX_CLIMB_KMS    = 0.05
+	VALIDATION_MAX_DEVIATION_KM = 25
+	// VALIDATION_MAX_GAP bounds how old the last accepted sample may be to
+	// compare against. Past it only the absolute bounds apply, which also
+	// stops one bad reference from quarantining everything after it.
+	VALIDATION_MAX_GAP = 600
+	// VALIDATION_MAX_SEED_GAP bounds the spacing of the two samples the
+	// propagation check derives a velocity from.
+	VALIDATION_MAX_SEED_GAP   = 60
+	VALIDATION_MAX_CLOCK_SKEW = 300
+	// VALIDATION_RESYNC_SAMPLES is how many consecutive quarantined samples
+	// that agree with each other replace the reference they failed against,
+	// so a bad reference does not quarantine everything after it.
+	VALIDATION_RESYNC_SAMPLES = 3
+)
+
+// positionViolation says why a sample was quarantined.
+type positionViolation struct {
+	rule   string
+	reason string
+}
+
+func violation(rule, format string, args ...any) *positionViolation {
+	return &positionViolation{rule: rule, reason: fmt.Sprintf(format, args...)}
+}
+
+// checkPosition validates a sample in kilometers against absolute bounds and
+// against the last accepted samples, oldest first, any of which may be nil.
+// It returns nil when the sample is plausible.
+func checkPosition(curr *models.ISSPosition, history []*models.ISSPosition, now time.Time) *positionViolation {
+	if curr.Altitude < VALIDATION_MIN_ALTITUDE_KM || curr.Altitude > VALIDATION_MAX_ALTITUDE_KM {
+		return violation(models.QuarantineRuleRange, "altitude %.1f km outside %d-%d km",
+			curr.Altitude, VALIDATION_MIN_ALTITUDE_KM, VALIDATION_MAX_ALTITUDE_KM)
+	}
+	if math.Abs(curr.Latitude) > VALIDATION_MAX_LATITUDE || math.Abs(curr.Longitude) > 180 {
+		return violation(models.QuarantineRuleRange, "latitude %.4f, longitude %.4f outside the ISS's reach",
+			curr.Latitude, curr.Longitude)
+	}
+	if curr.Velocity < VALIDATION_MIN_VELOCITY || curr.Velocity > VALIDATION_MAX_VELOCITY {
+		return violation(models.QuarantineRuleRange, "velocity %.0f km/h outside %.0f-%.0f km/h",
+			curr.Velocity, VALIDATION_MIN_VELOCITY, VALIDATION_MAX_VELOCITY)
+	}
+	if skew := curr.Timestamp - now.Unix(); skew > VALIDATION_MAX_CLOCK_SKEW {
+		return violation(models.QuarantineRuleTimestamp, "timestamp %d is %d s in the future", curr.Timestamp, skew)
+	}
+
+	var prev, beforePrev *models.ISSPosition
+	if n := len(history); n > 0 {
+		prev = history[n-1]
+		if n > 1 {
+			beforePrev = history[n-2]
+		}
+	}
+	if prev == nil {
+		return nil
+	}
+
+	dt := curr.Timestamp - prev.Timestamp
+	if dt < 0 {
+		return violation(models.QuarantineRuleTimestamp, "timestamp %d goes back %d s from the previous sample", curr.Timestamp, -dt)
+	}
+	if dt == 0 || dt > VALIDATION_MAX_GAP {
+		return nil
+	}
+
+	r := geodeticToECEF(curr.Latitude, curr.Longitude, curr.Altitude)
+	speed := r.sub(geodeticToECEF(prev.Latitude, prev.Longitude, prev.Altitude)).norm() / float64(dt)
+	if speed < VALIDATION_MIN_SPEED_KMS || speed > VALIDATION_MAX_SPEED_KMS {
+		return violation(models.QuarantineRuleSpeed, "implied speed %.2f km/s over %d s, expected %.1f-%.1f km/s",
+			speed, dt, VALIDATION_MIN_SPEED_KMS, VALIDATION_MAX_SPEED_KMS)
+	}
+
+	climb := math.Abs(curr.Altitude-prev.Altitude) / float64(dt)
+	if climb > VALIDATION_MAX_CLIMB_KMS {
+		return violation(models.QuarantineRuleAltitude, "altitude changed %.2f km in %d s",
+			curr.Altitude-prev.Altitude, dt)
+	}
+
+	if beforePrev == nil {
+		return nil
+	}
+	seedGap := prev.Timestamp - beforePrev.Timestamp
+	if seedGap <= 0 || seedGap > VALIDATION_MAX_SEED_GAP {
+		return nil
+	}
+
+	p := newPropagator(interpolateState(beforePrev, prev, prev.Timestamp))
+	for remaining := time.Duration(dt) * time.Second; remaining > 0; remaining -= PROPAGATION_STEP {
+		p.step(min(remaining, PROPAGATION_STEP))
+	}
+	lat, lon, alt := p.geodetic()
+	if deviation := r.sub(geodeticToECEF(lat, lon, alt)).norm(); deviation > VALIDATION_MAX_DEVIATION_KM {
+		return violation(models.QuarantineRulePropagated, "%.1f km from the orbit propagated over %d s from the previous samples",
+			deviation, dt)
+	}
+
+	return nil
+}
+
+// extendRejectedRun adds a quarantined sample to the run of consecutive
+// quarantined samples that agree with each other, or starts a new run with
+// it. Samples outside the absolute bounds never join a run, and a sample
+// upstream repeats only counts once.
+func extendRejectedRun(run []*models.ISSPosition, curr *models.ISSPosition, now time.Time) []*models.ISSPosition {
+	if checkPosition(curr, nil, now) != nil {
+		return nil
+	}
+
+	if n := len(run); n > 0 {
+		dt := curr.Timestamp - run[n-1].Timestamp
+		if dt == 0 {
+			return run
+		}
+		if dt > 0 && dt <= VALIDATION_MAX_GAP && checkPosition(curr, run[max(0, n-2):], now) == nil {
+			return append(run, curr)
+		}
+	}
+	return []*models.ISSPosition{curr}
+}
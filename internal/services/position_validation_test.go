@@ -0,0 +1,110 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+// validationTrack propagates an ISS-like orbit crossing the equator
+// northbound at ts and returns samples every PROPAGATION_STEP.
+func validationTrack(ts int64) []*models.ISSPosition {
+	inclination, swept := toRadians(51.6), toRadians(360.0/5550*10)
+	a := &models.ISSPosition{Altitude: 420, Timestamp: ts}
+	b := &models.ISSPosition{
+		Latitude:  toDegrees(math.Asin(math.Sin(inclination) * math.Sin(swept))),
+		Longitude: toDegrees(math.Atan2(math.Cos(inclination)*math.Sin(swept), math.Cos(swept))) - 10*360/86164.0,
+		Altitude:  420,
+		Timestamp: ts + 10,
+	}
+
+	track := newPropagator(interpolateState(a, b, ts)).track(time.Unix(ts+900, 0))
+	for _, point := range track {
+		point.Velocity = 27600
+	}
+	return track
+}
+
+func TestCheckPosition(t *testing.T) {
+	const ts = 1740000000
+	now := time.Unix(ts+900, 0)
+	track := validationTrack(ts)
+	history := track[10:12]
+
+	shifted := func(p *models.ISSPosition, change func(*models.ISSPosition)) *models.ISSPosition {
+		copied := *p
+		change(&copied)
+		return &copied
+	}
+
+	tests := []struct {
+		name    string
+		curr    *models.ISSPosition
+		history []*models.ISSPosition
+		rule    string
+	}{
+		{"next sample", track[12], history, ""},
+		{"a minute later", track[17], history, ""},
+		{"first sample", track[12], []*models.ISSPosition{nil, nil}, ""},
+		{"after an outage", shifted(track[80], func(p *models.ISSPosition) { p.Latitude += 5 }), history, ""},
+		{"altitude", shifted(track[12], func(p *models.ISSPosition) { p.Altitude = 4200 }), history, models.QuarantineRuleRange},
+		{"velocity", shifted(track[12], func(p *models.ISSPosition) { p.Velocity = 0 }), history, models.QuarantineRuleRange},
+		{"future", shifted(track[12], func(p *models.ISSPosition) { p.Timestamp += 3600 }), history, models.QuarantineRuleTimestamp},
+		{"regression", track[9], history, models.QuarantineRuleTimestamp},
+		{"teleport", shifted(track[12], func(p *models.ISSPosition) { p.Latitude += 5 }), history, models.QuarantineRuleSpeed},
+		{"altitude spike", shifted(track[12], func(p *models.ISSPosition) { p.Altitude += 2 }), history, models.QuarantineRuleAltitude},
+		{"off orbit", shifted(track[17], func(p *models.ISSPosition) { p.Longitude -= 0.4 }), history, models.QuarantineRulePropagated},
+	}
+
+	for _, tt := range tests {
+		got := checkPosition(tt.curr, tt.history, now)
+		switch {
+		case got == nil && tt.rule != "":
+			t.Errorf("%s: accepted, want %s violation", tt.name, tt.rule)
+		case got != nil && got.rule != tt.rule:
+			t.Errorf("%s: %s violation (%s), want %q", tt.name, got.rule, got.reason, tt.rule)
+		}
+	}
+}
+
+func TestExtendRejectedRun(t *testing.T) {
+	const ts = 1740000000
+	now := time.Unix(ts+900, 0)
+	track := validationTrack(ts)
+
+	// A bad reference off the orbit quarantines the real samples after it.
+	reference := []*models.ISSPosition{track[10], track[11]}
+	bad := *track[11]
+	bad.Latitude += 3
+	reference[1] = &bad
+	for _, curr := range track[12:15] {
+		if checkPosition(curr, reference, now) == nil {
+			t.Fatalf("sample at %d accepted against the bad reference", curr.Timestamp)
+		}
+	}
+
+	var run []*models.ISSPosition
+	run = extendRejectedRun(run, track[12], now)
+	run = extendRejectedRun(run, track[12], now) // repeated upstream
+	run = extendRejectedRun(run, track[13], now)
+	if len(run) != 2 {
+		t.Fatalf("run of %d samples, want 2 without the repeat", len(run))
+	}
+	if run = extendRejectedRun(run, track[14], now); len(run) != VALIDATION_RESYNC_SAMPLES {
+		t.Errorf("run of %d agreeing samples, want %d", len(run), VALIDATION_RESYNC_SAMPLES)
+	}
+
+	// A sample off the orbit starts a new run; one out of range never joins.
+	jump := *track[15]
+	jump.Longitude += 2
+	if run = extendRejectedRun(run, &jump, now); len(run) != 1 || run[0] != &jump {
+		t.Errorf("disagreeing sample extended the run to %d", len(run))
+	}
+	high := *track[16]
+	high.Altitude = 4200
+	if run = extendRejectedRun(run, &high, now); run != nil {
+		t.Errorf("out-of-range sample kept a run of %d", len(run))
+	}
+}
@@ -7,11 +7,12 @@ import (
 )
 
 type PostService struct {
-	db *gorm.DB
+	db  *gorm.DB
+	hub *EventHub
 }
 
-func NewPostService(db *gorm.DB) *PostService {
-	return &PostService{db: db}
+func NewPostService(db *gorm.DB, hub *EventHub) *PostService {
+	return &PostService{db: db, hub: hub}
 }
 
 func (s *PostService) CreatePost(
@@ -41,6 +42,9 @@ func (s *PostService) CreatePost(
 	if err := s.db.Create(post).Error; err != nil {
 		return nil, err
 	}
+
+	s.hub.Publish(models.LiveEventPostPublished, post)
+
 	return post, nil
 }
 
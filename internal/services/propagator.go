@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	EARTH_MU         = 398600.4418 // km^3/s^2
+	EARTH_J2         = 1.08262668e-3
+	PROPAGATION_STEP = 10 * time.Second
+)
+
+// propagator advances an ISS state with two-body gravity plus the J2
+// oblateness term, integrated with fixed-step RK4. Drag is ignored, so
+// predictions drift by a few kilometers per day; seed a fresh propagator
+// for every request instead of reusing one.
+//
+// The state is kept in TEME, whose Z axis is close enough to the Earth's
+// rotation axis for J2.
+type propagator struct {
+	t time.Time
+	r vec3 // km
+	v vec3 // km/s
+}
+
+func newPropagator(st *orbitalState) *propagator {
+	t := time.Unix(st.Timestamp, 0).UTC()
+	r, v := stateInFrame(FRAME_TEME, st)
+	return &propagator{t: t, r: r, v: v}
+}
+
+// seedPropagator starts a propagator from the ISS state at timestamp.
+func (s *ISSService) seedPropagator(timestamp int64) (*propagator, error) {
+	st, err := s.stateAt(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ISS state: %w", err)
+	}
+	return newPropagator(st), nil
+}
+
+func gravityAcceleration(r vec3) vec3 {
+	rNorm := r.norm()
+	r2 := rNorm * rNorm
+	a := r.scale(-EARTH_MU / (r2 * rNorm))
+
+	zRatio := r[2] * r[2] / r2
+	k := -1.5 * EARTH_J2 * EARTH_MU * WGS84_A_KM * WGS84_A_KM / (r2 * r2 * rNorm)
+
+	return a.add(vec3{
+		k * r[0] * (1 - 5*zRatio),
+		k * r[1] * (1 - 5*zRatio),
+		k * r[2] * (3 - 5*zRatio),
+	})
+}
+
+func (p *propagator) step(dt time.Duration) {
+	h := dt.Seconds()
+
+	k1r, k1v := p.v, gravityAcceleration(p.r)
+	k2r, k2v := p.v.add(k1v.scale(h/2)), gravityAcceleration(p.r.add(k1r.scale(h/2)))
+	k3r, k3v := p.v.add(k2v.scale(h/2)), gravityAcceleration(p.r.add(k2r.scale(h/2)))
+	k4r, k4v := p.v.add(k3v.scale(h)), gravityAcceleration(p.r.add(k3r.scale(h)))
+
+	p.r = p.r.add(k1r.add(k2r.scale(2)).add(k3r.scale(2)).add(k4r).scale(h / 6))
+	p.v = p.v.add(k1v.add(k2v.scale(2)).add(k3v.scale(2)).add(k4v).scale(h / 6))
+	p.t = p.t.Add(dt)
+}
+
+// geodetic returns the sub-satellite point and altitude at the current step.
+func (p *propagator) geodetic() (lat, lon, alt float64) {
+	return ecefToGeodetic(rotZ(gmstRadians(p.t)).mulVec(p.r))
+}
+
+// track samples the propagated ground track every PROPAGATION_STEP until
+// end, starting with the seed.
+func (p *propagator) track(end time.Time) []*models.ISSPosition {
+	var points []*models.ISSPosition
+	for !p.t.After(end) {
+		lat, lon, alt := p.geodetic()
+		points = append(points, &models.ISSPosition{
+			Name:      "iss",
+			Latitude:  lat,
+			Longitude: lon,
+			Altitude:  alt,
+			Velocity:  p.v.norm() * 3600,
+			Timestamp: p.t.Unix(),
+			Units:     "kilometers",
+		})
+		p.step(PROPAGATION_STEP)
+	}
+	return points
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPropagatorCircularOrbit(t *testing.T) {
+	radius := WGS84_A_KM + 420
+	speed := math.Sqrt(EARTH_MU / radius)
+	inclination := toRadians(51.6)
+
+	p := &propagator{
+		t: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		r: vec3{radius, 0, 0},
+		v: vec3{0, speed * math.Cos(inclination), speed * math.Sin(inclination)},
+	}
+	start := p.r
+
+	period := time.Duration(2 * math.Pi * math.Sqrt(radius*radius*radius/EARTH_MU) * float64(time.Second))
+	maxLat := 0.0
+	for elapsed := time.Duration(0); elapsed < period; elapsed += PROPAGATION_STEP {
+		p.step(PROPAGATION_STEP)
+
+		if altitude := p.r.norm() - WGS84_A_KM; altitude < 400 || altitude > 440 {
+			t.Fatalf("altitude drifted to %f km after %s", altitude, elapsed)
+		}
+		lat, _, _ := p.geodetic()
+		maxLat = math.Max(maxLat, lat)
+	}
+
+	// J2 perturbs the orbit, but one revolution should close within ~100 km.
+	if miss := p.r.sub(start).norm(); miss > 100 {
+		t.Errorf("orbit did not close: %f km from start", miss)
+	}
+	if maxLat < 51 || maxLat > 52.5 {
+		t.Errorf("maximum latitude %f, want about 51.6", maxLat)
+	}
+}
+
+func TestInSAA(t *testing.T) {
+	cases := []struct {
+		lat, lon float64
+		inside   bool
+	}{
+		{-25, -45, true},
+		{-30, -70, true},
+		{-35, 0, true},
+		{10, -45, false},
+		{-25, 100, false},
+		{-35, 40, false},
+		{50, 20, false},
+	}
+
+	for _, c := range cases {
+		if got := InSAA(c.lat, c.lon); got != c.inside {
+			t.Errorf("InSAA(%v, %v) = %v, want %v", c.lat, c.lon, got, c.inside)
+		}
+	}
+}
+
+func TestSAAPassesFollowPassAtHorizon(t *testing.T) {
+	orbit := func() *propagator {
+		radius := WGS84_A_KM + 420
+		speed := math.Sqrt(EARTH_MU / radius)
+		inclination := toRadians(51.6)
+		return &propagator{
+			t: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			r: vec3{radius, 0, 0},
+			v: vec3{0, speed * math.Cos(inclination), speed * math.Sin(inclination)},
+		}
+	}
+
+	start := orbit().t
+	passes := saaPasses(orbit(), start.Add(24*time.Hour))
+	if len(passes) == 0 {
+		t.Fatal("no SAA passes in a day")
+	}
+
+	first := passes[0]
+	end := time.Unix(first.EntryTime, 0).Add(time.Minute)
+	cut := saaPasses(orbit(), end)
+	if len(cut) != 1 || cut[0] != first {
+		t.Errorf("passes with the horizon inside the first one = %+v, want %+v", cut, first)
+	}
+}
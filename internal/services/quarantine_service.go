@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrQuarantineNotFound = errors.New("quarantined position not found")
+	ErrAlreadyReviewed    = errors.New("quarantined position already reviewed")
+	ErrInvalidReview      = errors.New("invalid review")
+)
+
+// QuarantineService lets admins review the samples the collector set aside.
+type QuarantineService struct {
+	db *gorm.DB
+}
+
+func NewQuarantineService(db *gorm.DB) *QuarantineService {
+	return &QuarantineService{db: db}
+}
+
+// GetQuarantined lists quarantined samples, newest first. An empty status
+// lists all of them.
+func (s *QuarantineService) GetQuarantined(status string, limit int) ([]models.QuarantinedPosition, error) {
+	statuses := []string{models.QuarantineStatusPending, models.QuarantineStatusApproved, models.QuarantineStatusRejected}
+	if status != "" && !slices.Contains(statuses, status) {
+		return nil, fmt.Errorf("%w: status must be one of %v", ErrInvalidReview, statuses)
+	}
+
+	query := s.db.Model(&models.QuarantinedPosition{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+
+	var samples []models.QuarantinedPosition
+	if err := query.Order("timestamp desc").Limit(limit).Find(&samples).Error; err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// Review settles a pending sample. An approved sample is copied into the
+// position history; collector hooks don't see it, as it arrives out of
+// order.
+func (s *QuarantineService) Review(id uint, approve bool) (*models.QuarantinedPosition, error) {
+	var sample models.QuarantinedPosition
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&sample, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %d", ErrQuarantineNotFound, id)
+			}
+			return err
+		}
+		if sample.Status != models.QuarantineStatusPending {
+			return fmt.Errorf("%w: %d is %s", ErrAlreadyReviewed, id, sample.Status)
+		}
+
+		if approve {
+			position := sample.Position()
+			if err := tx.Where("satellite_id = ? AND timestamp = ?", position.SatelliteID, position.Timestamp).FirstOrCreate(position).Error; err != nil {
+				return err
+			}
+			sample.Status = models.QuarantineStatusApproved
+		} else {
+			sample.Status = models.QuarantineStatusRejected
+		}
+
+		now := time.Now()
+		sample.ReviewedAt = &now
+		return tx.Save(&sample).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sample, nil
+}
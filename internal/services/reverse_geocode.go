@@ -0,0 +1,179 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"iss-model-backend/internal/models"
+)
+
+// COASTAL_RADIUS_KM is how close the nearest place must be for a point
+// outside the land outlines, or inside a sea outline, to count as that
+// place's country. It covers coastal cities and small islands that the
+// half-degree outlines leave out, and is kept well below the distance from
+// a coast to open water worth naming.
+const COASTAL_RADIUS_KM = 50.0
+
+// countryPlacesCSV holds capitals and towns (name, country_code, country,
+// latitude, longitude) of countries and territories with no place in
+// cities.csv, which only lists places above 100,000 inhabitants, and of
+// sparsely populated interiors such as the Sahara, the outback and
+// Greenland. Together with the cities they decide which country a land
+// point belongs to; NearestCity doesn't use them.
+//
+//go:embed data/country_places.csv
+var countryPlacesCSV []byte
+
+// countryPlaces are the cities followed by the country places, indexed for
+// reverse geocoding.
+var (
+	countryPlaces      = append(slices.Clip(cities), mustLoadCountryPlaces()...)
+	countryPlacesIndex = newSpatialIndex(len(countryPlaces), func(i int) (float64, float64) {
+		return countryPlaces[i].lat, countryPlaces[i].lon
+	})
+)
+
+func mustLoadCountryPlaces() []city {
+	rows := readEmbeddedCSV("country_places.csv", countryPlacesCSV, 5)
+
+	result := make([]city, len(rows))
+	for i, row := range rows {
+		lat, lon := mustParseCoordinates("country_places.csv", row, 3)
+		result[i] = city{
+			name:        row[0],
+			countryCode: row[1],
+			country:     row[2],
+			lat:         lat,
+			lon:         lon,
+		}
+	}
+	return result
+}
+
+// seasJSON outlines the enclosed seas the ISS crosses as a GeoJSON
+// FeatureCollection with code and name properties. The outlines follow the
+// coasts only to within a few tens of kilometers.
+//
+//go:embed data/seas.json
+var seasJSON []byte
+
+type seaOutline struct {
+	region models.Region
+	ring   models.Coordinates
+}
+
+var seas = mustLoadSeas()
+
+func mustLoadSeas() []seaOutline {
+	var collection struct {
+		Features []struct {
+			Properties struct {
+				Code string `json:"code"`
+				Name string `json:"name"`
+			} `json:"properties"`
+			Geometry struct {
+				Coordinates []models.Coordinates `json:"coordinates"`
+			} `json:"geometry"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(seasJSON, &collection); err != nil || len(collection.Features) == 0 {
+		panic(fmt.Sprintf("invalid embedded seas: %v", err))
+	}
+
+	result := make([]seaOutline, len(collection.Features))
+	for i, feature := range collection.Features {
+		result[i] = seaOutline{
+			region: models.Region{
+				Code: feature.Properties.Code,
+				Name: feature.Properties.Name,
+				Type: models.RegionTypeSea,
+			},
+			ring: feature.Geometry.Coordinates[0],
+		}
+	}
+	return result
+}
+
+// ReverseGeocode names the country or body of water below a point, offline.
+// The land outlines of the base map decide between land and water, and the
+// nearest city or country place only decides which country a land point
+// belongs to, so points near borders are attributed to a neighbour now and
+// then. Within COASTAL_RADIUS_KM of a place a point is always that place's
+// country; otherwise a sea outline names the sea, a land outline the
+// country, and what is left is open ocean, or the nearest country in the
+// few gaps between the outlines inside Eurasia.
+func ReverseGeocode(lat, lon float64) models.Region {
+	place := countryPlaces[countryPlacesIndex.nearest(lat, lon)]
+	distance := haversineKm(lat, lon, place.lat, place.lon)
+	country := models.Region{Code: place.countryCode, Name: place.country, Type: models.RegionTypeCountry}
+
+	if distance <= COASTAL_RADIUS_KM {
+		return country
+	}
+
+	for _, sea := range seas {
+		if pointInPolygon(lat, lon, sea.ring) {
+			return sea.region
+		}
+	}
+
+	if onLand(lat, lon) {
+		return country
+	}
+
+	if ocean, ok := oceanAt(lat, lon); ok {
+		return ocean
+	}
+	return country
+}
+
+// onLand reports whether a point lies inside one of the land outlines and
+// outside its holes.
+func onLand(lat, lon float64) bool {
+	for _, rings := range land {
+		if !pointInPolygon(lat, lon, rings[0]) {
+			continue
+		}
+
+		inHole := false
+		for _, hole := range rings[1:] {
+			if pointInPolygon(lat, lon, hole) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// oceanAt splits open water between the oceans along rough meridians: the
+// Atlantic reaches to the Gulf of Mexico north of 10°N and to Cape Horn
+// south of it, and the Indian Ocean runs from Africa to Tasmania south of
+// Asia, leaving the Indonesian seas to the Pacific. It returns false north
+// of the Indian Ocean between Africa and China.
+func oceanAt(lat, lon float64) (models.Region, bool) {
+	ocean := func(code, name string) (models.Region, bool) {
+		return models.Region{Code: code, Name: name, Type: models.RegionTypeOcean}, true
+	}
+
+	switch {
+	case lat >= 66.5:
+		return ocean("arctic_ocean", "Arctic Ocean")
+	case lat <= -60:
+		return ocean("southern_ocean", "Southern Ocean")
+	case lon >= 20 && lon < 100 && lat >= 25:
+		return models.Region{}, false
+	case lon >= 20 && lon < 147 && lat < 25 && !(lon >= 100 && lat > -5):
+		return ocean("indian_ocean", "Indian Ocean")
+	case lon >= 20:
+		return ocean("pacific_ocean", "Pacific Ocean")
+	case lat >= 10 && lon >= -100, lat < 10 && lon >= -70:
+		return ocean("atlantic_ocean", "Atlantic Ocean")
+	}
+	return ocean("pacific_ocean", "Pacific Ocean")
+}
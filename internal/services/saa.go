@@ -0,0 +1,181 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	SAA_DEFAULT_HOURS = 6
+	SAA_MAX_HOURS     = 24
+)
+
+// saaBoundaryJSON outlines the South Atlantic Anomaly at ISS altitude as a
+// GeoJSON polygon: the contour where the IGRF-13 (2020.0) field strength at
+// 400 km drops below 22,500 nT, traced every 5° of azimuth around the field
+// minimum (about 19,150 nT at 23.5°S 58.1°W) by data/gen_saa.go. The
+// threshold puts the boundary at about 94°W-17°E and 49°S-2°N, the extent
+// of the trapped proton flux seen at ISS altitude.
+//
+//go:generate go run data/gen_saa.go -threshold 22500 -o data/saa_boundary.json
+//go:embed data/saa_boundary.json
+var saaBoundaryJSON []byte
+
+var saaBoundary = mustLoadSAABoundary()
+
+func mustLoadSAABoundary() models.Coordinates {
+	var geometry struct {
+		Coordinates []models.Coordinates `json:"coordinates"`
+	}
+	if err := json.Unmarshal(saaBoundaryJSON, &geometry); err != nil || len(geometry.Coordinates) == 0 {
+		panic(fmt.Sprintf("invalid embedded SAA boundary: %v", err))
+	}
+	return geometry.Coordinates[0]
+}
+
+// InSAA reports whether a sub-satellite point lies inside the SAA boundary.
+func InSAA(lat, lon float64) bool {
+	return pointInPolygon(lat, lon, saaBoundary)
+}
+
+// SAABoundaryFeature returns the boundary as a GeoJSON feature, styled with
+// simplestyle properties for use as a shaded map overlay.
+func SAABoundaryFeature() models.GeoJSONFeature {
+	return models.NewGeoJSONFeature(models.GeoJSONPolygon, []models.Coordinates{saaBoundary}, map[string]any{
+		"name":         "South Atlantic Anomaly",
+		"fill":         "#d62728",
+		"fill-opacity": 0.25,
+		"stroke":       "#d62728",
+	})
+}
+
+// GetSAAStatus reports whether the ISS is inside the SAA now and predicts
+// the passes in the next hours by propagating the current state.
+func (s *ISSService) GetSAAStatus(hours int) (*models.SAAStatusResponse, error) {
+	position, err := s.GetCurrentPosition("kilometers")
+	if err != nil {
+		return nil, err
+	}
+
+	passes, err := s.PredictSAAPasses(position.Timestamp, time.Duration(hours)*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SAAStatusResponse{
+		Timestamp:      position.Timestamp,
+		InSAA:          InSAA(position.Latitude, position.Longitude),
+		Latitude:       position.Latitude,
+		Longitude:      position.Longitude,
+		UpcomingPasses: passes,
+	}, nil
+}
+
+// PredictSAAPasses lists SAA transits that start between from and
+// from+horizon. A pass already in progress at from starts at from; one still
+// in progress at the horizon is followed to its exit.
+func (s *ISSService) PredictSAAPasses(from int64, horizon time.Duration) ([]models.SAAPass, error) {
+	p, err := s.seedPropagator(from)
+	if err != nil {
+		return nil, err
+	}
+
+	return saaPasses(p, time.Unix(from, 0).Add(horizon)), nil
+}
+
+func saaPasses(p *propagator, end time.Time) []models.SAAPass {
+	passes := []models.SAAPass{}
+	var current *models.SAAPass
+
+	visit := func(point *models.ISSPosition) {
+		inside := InSAA(point.Latitude, point.Longitude)
+
+		switch {
+		case inside && current == nil:
+			current = &models.SAAPass{
+				EntryTime:      point.Timestamp,
+				EntryLatitude:  point.Latitude,
+				EntryLongitude: point.Longitude,
+			}
+		case !inside && current != nil:
+			current.ExitTime = point.Timestamp
+			current.ExitLatitude = point.Latitude
+			current.ExitLongitude = point.Longitude
+			current.DurationSeconds = current.ExitTime - current.EntryTime
+			passes = append(passes, *current)
+			current = nil
+		}
+	}
+
+	for _, point := range p.track(end) {
+		visit(point)
+	}
+
+	// A transit lasts well under an hour, so this always reaches the exit.
+	if current != nil {
+		for _, point := range p.track(end.Add(time.Hour)) {
+			visit(point)
+			if current == nil {
+				break
+			}
+		}
+	}
+
+	return passes
+}
+
+// GetGroundTrack returns stored positions between start and end as GeoJSON
+// line segments, split at the antimeridian and wherever the track enters or
+// leaves the SAA, plus the SAA boundary as a shaded polygon.
+func (s *ISSService) GetGroundTrack(start, end int64) (*models.GeoJSONFeatureCollection, error) {
+	positions, err := s.GetPositionsInRange(start, end, "kilometers")
+	if err != nil {
+		return nil, err
+	}
+
+	features := append([]models.GeoJSONFeature{SAABoundaryFeature()}, splitTrack(positions)...)
+
+	return models.NewGeoJSONFeatureCollection(features), nil
+}
+
+func splitTrack(positions []*models.ISSPosition) []models.GeoJSONFeature {
+	var features []models.GeoJSONFeature
+	var line models.Coordinates
+	inSAA := false
+
+	flush := func() {
+		if len(line) >= 2 {
+			features = append(features, models.NewGeoJSONFeature(models.GeoJSONLineString, line, map[string]any{
+				"in_saa": inSAA,
+			}))
+		}
+		line = nil
+	}
+
+	for i, position := range positions {
+		point := [2]float64{position.Longitude, position.Latitude}
+		inside := InSAA(position.Latitude, position.Longitude)
+
+		if i > 0 {
+			prev := positions[i-1]
+			switch {
+			case prev.Longitude-position.Longitude > 180 || position.Longitude-prev.Longitude > 180:
+				flush()
+			case inside != inSAA:
+				// Close the segment on the new point so the line stays connected.
+				line = append(line, point)
+				flush()
+			}
+		}
+
+		inSAA = inside
+		line = append(line, point)
+	}
+	flush()
+
+	return features
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSatelliteNotFound = errors.New("satellite not found")
+	ErrInvalidSatellite  = errors.New("invalid satellite")
+)
+
+const (
+	ISS_NAME                   = "ISS (ZARYA)"
+	SATELLITE_DEFAULT_INTERVAL = 60 // seconds
+	// SATELLITE_MIN_INTERVAL keeps the collectors, which share the upstream
+	// rate limit, from starving each other.
+	SATELLITE_MIN_INTERVAL = 10
+	SATELLITE_MAX_INTERVAL = 24 * 3600
+)
+
+// SatelliteService keeps the satellite catalog and runs a collector for every
+// active satellite at its interval. The ISS is collected by the ISS service
+// itself, with its hooks and validation; other satellites by ForSatellite
+// views of it.
+type SatelliteService struct {
+	db  *gorm.DB
+	iss *ISSService
+
+	mu         sync.Mutex
+	collectors map[int]chan struct{}
+	views      map[int]*ISSService
+}
+
+func NewSatelliteService(db *gorm.DB, iss *ISSService) *SatelliteService {
+	service := &SatelliteService{
+		db:         db,
+		iss:        iss,
+		collectors: make(map[int]chan struct{}),
+		views:      make(map[int]*ISSService),
+	}
+
+	seed := models.Satellite{
+		NoradID:            ISS_ID,
+		Name:               ISS_NAME,
+		CollectionInterval: int(COLLECTION_INTERVAL / time.Second),
+		Active:             true,
+	}
+	if err := db.Where("norad_id = ?", ISS_ID).FirstOrCreate(&seed).Error; err != nil {
+		log.Printf("Failed to add the ISS to the satellite catalog: %v", err)
+	}
+
+	var satellites []models.Satellite
+	if err := db.Where("active").Find(&satellites).Error; err != nil {
+		log.Printf("Failed to load the satellite catalog, collecting the ISS only: %v", err)
+		satellites = []models.Satellite{seed}
+	}
+	for i := range satellites {
+		service.schedule(&satellites[i])
+	}
+
+	return service
+}
+
+func (s *SatelliteService) GetSatellites() ([]models.Satellite, error) {
+	var satellites []models.Satellite
+	if err := s.db.Order("norad_id asc").Find(&satellites).Error; err != nil {
+		return nil, err
+	}
+	return satellites, nil
+}
+
+func (s *SatelliteService) GetSatellite(noradID int) (*models.Satellite, error) {
+	var satellite models.Satellite
+	if err := s.db.First(&satellite, "norad_id = ?", noradID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %d", ErrSatelliteNotFound, noradID)
+		}
+		return nil, err
+	}
+	return &satellite, nil
+}
+
+// CreateSatellite adds a satellite to the catalog and, when it is active,
+// starts collecting it.
+func (s *SatelliteService) CreateSatellite(satellite *models.Satellite) (*models.Satellite, error) {
+	if err := validateSatellite(satellite); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetSatellite(satellite.NoradID); err == nil {
+		return nil, fmt.Errorf("%w: %d is already in the catalog", ErrInvalidSatellite, satellite.NoradID)
+	} else if !errors.Is(err, ErrSatelliteNotFound) {
+		return nil, err
+	}
+
+	if err := s.probe(satellite.NoradID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(satellite).Error; err != nil {
+		return nil, err
+	}
+
+	s.schedule(satellite)
+	return satellite, nil
+}
+
+// probe asks the position API for a satellite, so that only satellites it
+// serves are added; the collector would fail on every tick for the rest.
+func (s *SatelliteService) probe(noradID int) error {
+	if _, err := s.iss.ForSatellite(noradID).fetchFromAPI(0, "kilometers"); err != nil {
+		var statusErr *UpstreamStatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 && statusErr.StatusCode != http.StatusTooManyRequests {
+			return fmt.Errorf("%w: %d is not served by the position API", ErrInvalidSatellite, noradID)
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateSatellite renames a satellite or changes its schedule. The ISS can't
+// be deactivated: the ISS endpoints depend on its collection.
+func (s *SatelliteService) UpdateSatellite(noradID int, update *models.Satellite) (*models.Satellite, error) {
+	satellite, err := s.GetSatellite(noradID)
+	if err != nil {
+		return nil, err
+	}
+
+	update.NoradID = noradID
+	if err := validateSatellite(update); err != nil {
+		return nil, err
+	}
+	if noradID == ISS_ID && !update.Active {
+		return nil, fmt.Errorf("%w: the ISS can't be deactivated", ErrInvalidSatellite)
+	}
+
+	satellite.Name = update.Name
+	satellite.CollectionInterval = update.CollectionInterval
+	satellite.Active = update.Active
+
+	if err := s.db.Save(satellite).Error; err != nil {
+		return nil, err
+	}
+
+	s.schedule(satellite)
+	return satellite, nil
+}
+
+// DeleteSatellite removes a satellite from the catalog and stops collecting
+// it. Its stored positions age out with the retention window.
+func (s *SatelliteService) DeleteSatellite(noradID int) error {
+	if noradID == ISS_ID {
+		return fmt.Errorf("%w: the ISS can't be removed", ErrInvalidSatellite)
+	}
+
+	satellite, err := s.GetSatellite(noradID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Delete(satellite).Error; err != nil {
+		return err
+	}
+
+	satellite.Active = false
+	s.schedule(satellite)
+
+	s.mu.Lock()
+	delete(s.views, noradID)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// ServiceFor returns the tracking service of a satellite in the catalog.
+func (s *SatelliteService) ServiceFor(noradID int) (*ISSService, error) {
+	if noradID == ISS_ID {
+		return s.iss, nil
+	}
+
+	if _, err := s.GetSatellite(noradID); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.service(noradID), nil
+}
+
+// service returns the service collecting a satellite, reusing views so each
+// keeps its collection history. s.mu must be held.
+func (s *SatelliteService) service(noradID int) *ISSService {
+	if noradID == ISS_ID {
+		return s.iss
+	}
+
+	view, ok := s.views[noradID]
+	if !ok {
+		view = s.iss.ForSatellite(noradID)
+		s.views[noradID] = view
+	}
+	return view
+}
+
+// schedule restarts the collector of a satellite at its interval, or stops
+// it when the satellite is inactive.
+func (s *SatelliteService) schedule(satellite *models.Satellite) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stop, ok := s.collectors[satellite.NoradID]; ok {
+		close(stop)
+		delete(s.collectors, satellite.NoradID)
+	}
+	if !satellite.Active {
+		return
+	}
+
+	stop := make(chan struct{})
+	s.collectors[satellite.NoradID] = stop
+	interval := time.Duration(satellite.CollectionInterval) * time.Second
+
+	log.Printf("Collecting %s (%d) every %s", satellite.Name, satellite.NoradID, interval)
+	go collectEvery(s.service(satellite.NoradID), interval, stop)
+}
+
+func collectEvery(service *ISSService, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		service.collectData()
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func validateSatellite(satellite *models.Satellite) error {
+	if satellite.NoradID <= 0 {
+		return fmt.Errorf("%w: norad_id must be a positive NORAD catalog number", ErrInvalidSatellite)
+	}
+	if satellite.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidSatellite)
+	}
+
+	if satellite.CollectionInterval == 0 {
+		satellite.CollectionInterval = SATELLITE_DEFAULT_INTERVAL
+	}
+	if satellite.CollectionInterval < SATELLITE_MIN_INTERVAL || satellite.CollectionInterval > SATELLITE_MAX_INTERVAL {
+		return fmt.Errorf("%w: collection_interval must be between %d and %d seconds",
+			ErrInvalidSatellite, SATELLITE_MIN_INTERVAL, SATELLITE_MAX_INTERVAL)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestValidateSatellite(t *testing.T) {
+	satellite := &models.Satellite{NoradID: 48274, Name: "CSS (TIANHE)"}
+	if err := validateSatellite(satellite); err != nil {
+		t.Fatalf("validateSatellite: %v", err)
+	}
+	if satellite.CollectionInterval != SATELLITE_DEFAULT_INTERVAL {
+		t.Errorf("collection interval = %d, want the default %d", satellite.CollectionInterval, SATELLITE_DEFAULT_INTERVAL)
+	}
+
+	for _, invalid := range []models.Satellite{
+		{NoradID: 0, Name: "unknown"},
+		{NoradID: 48274},
+		{NoradID: 48274, Name: "CSS (TIANHE)", CollectionInterval: 1},
+	} {
+		if err := validateSatellite(&invalid); !errors.Is(err, ErrInvalidSatellite) {
+			t.Errorf("validateSatellite(%+v) = %v, want ErrInvalidSatellite", invalid, err)
+		}
+	}
+}
+
+func TestProbeSatellite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/satellites/%d", ISS_ID):
+			w.Write([]byte(`{"id": 25544, "latitude": 50.1, "longitude": 19.9, "altitude": 418.2, "timestamp": 1740855300}`))
+		case "/satellites/48274":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "satellite not found", "status": 404}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	baseURL := BASE_URL
+	BASE_URL = server.URL
+	defer func() { BASE_URL = baseURL }()
+
+	service := &SatelliteService{iss: &ISSService{upstream: NewUpstreamClient(FixtureConfig{}), satelliteID: ISS_ID}}
+
+	if err := service.probe(ISS_ID); err != nil {
+		t.Errorf("probe(%d) = %v, want the ISS accepted", ISS_ID, err)
+	}
+	if err := service.probe(48274); !errors.Is(err, ErrInvalidSatellite) {
+		t.Errorf("probe(48274) = %v, want ErrInvalidSatellite", err)
+	}
+	if err := service.probe(20580); err == nil || errors.Is(err, ErrInvalidSatellite) {
+		t.Errorf("probe(20580) = %v, want the upstream failure rather than a rejection", err)
+	}
+}
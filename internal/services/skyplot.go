@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	SKY_PLOT_SIZE   = 440
+	SKY_PLOT_RADIUS = 180
+	SKY_PLOT_CENTER = SKY_PLOT_SIZE / 2
+	// The caption and legend sit below the plot.
+	SKY_PLOT_HEIGHT = SKY_PLOT_SIZE + 60
+)
+
+// skyPlotPoint projects azimuth and elevation onto the plot: zenith in the
+// middle, the horizon on the outer circle, north up and east to the right
+// as when looking up at the sky lying on your back with your head north.
+func skyPlotPoint(azimuth, elevation float64) (x, y float64) {
+	r := SKY_PLOT_RADIUS * (90 - math.Max(0, elevation)) / 90
+	az := toRadians(azimuth)
+	return SKY_PLOT_CENTER + r*math.Sin(az), SKY_PLOT_CENTER - r*math.Cos(az)
+}
+
+// renderSkyPlot draws a pass as a printable SVG polar plot: the path across
+// the sky, solid where the ISS is sunlit and dashed where it is eclipsed,
+// with AOS, TCA and LOS marked and labelled in UTC.
+func renderSkyPlot(pass *models.ObserverPass) string {
+	var b strings.Builder
+	aos := time.Unix(pass.AOS.Timestamp, 0).UTC()
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		SKY_PLOT_SIZE, SKY_PLOT_HEIGHT, SKY_PLOT_SIZE, SKY_PLOT_HEIGHT)
+	fmt.Fprintf(&b, "<title>ISS pass %s UTC</title>\n", aos.Format("2006-01-02 15:04"))
+	b.WriteString(`<rect width="100%" height="100%" fill="#ffffff"/>` + "\n")
+
+	// Horizon, elevation rings and cardinal directions.
+	fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="#f4f6fa" stroke="#333333" stroke-width="1.5"/>`+"\n",
+		SKY_PLOT_CENTER, SKY_PLOT_CENTER, SKY_PLOT_RADIUS)
+	for _, elevation := range []int{30, 60} {
+		r := SKY_PLOT_RADIUS * (90 - elevation) / 90
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="#b8bec9" stroke-dasharray="3 3"/>`+"\n",
+			SKY_PLOT_CENTER, SKY_PLOT_CENTER, r)
+		fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="10" fill="#7a828f">%d°</text>`+"\n",
+			SKY_PLOT_CENTER+3, SKY_PLOT_CENTER-r-3, elevation)
+	}
+	fmt.Fprintf(&b, `<path d="M%d %dV%dM%d %dH%d" stroke="#d5d9e0"/>`+"\n",
+		SKY_PLOT_CENTER, SKY_PLOT_CENTER-SKY_PLOT_RADIUS, SKY_PLOT_CENTER+SKY_PLOT_RADIUS,
+		SKY_PLOT_CENTER-SKY_PLOT_RADIUS, SKY_PLOT_CENTER, SKY_PLOT_CENTER+SKY_PLOT_RADIUS)
+	for i, label := range []string{"N", "E", "S", "W"} {
+		x, y := skyPlotPoint(float64(i*90), -1)
+		x += 12 * math.Sin(toRadians(float64(i*90)))
+		y -= 12 * math.Cos(toRadians(float64(i*90)))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-size="14" font-weight="bold" text-anchor="middle" dominant-baseline="central" fill="#333333">%s</text>`+"\n",
+			x, y, label)
+	}
+
+	// The path, split wherever the ISS enters or leaves the Earth's shadow.
+	// Each segment ends on the first point of the next so the line is
+	// continuous.
+	segment := []models.SkyPoint{pass.Path[0]}
+	flush := func() {
+		if len(segment) < 2 {
+			return
+		}
+		points := make([]string, len(segment))
+		for i, p := range segment {
+			x, y := skyPlotPoint(p.Azimuth, p.Elevation)
+			points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		style := `stroke="#e8a317" stroke-width="3.5"`
+		if !segment[0].Sunlit {
+			style = `stroke="#5b6472" stroke-width="2.5" stroke-dasharray="5 4"`
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" %s stroke-linecap="round" stroke-linejoin="round"/>`+"\n",
+			strings.Join(points, " "), style)
+	}
+	for _, p := range pass.Path[1:] {
+		segment = append(segment, p)
+		if p.Sunlit != segment[0].Sunlit {
+			flush()
+			segment = []models.SkyPoint{p}
+		}
+	}
+	flush()
+
+	markers := []struct {
+		label string
+		point models.SkyPoint
+		color string
+	}{
+		{"AOS", pass.AOS, "#1a9850"},
+		{"TCA", pass.TCA, "#d73027"},
+		{"LOS", pass.LOS, "#4575b4"},
+	}
+	for _, marker := range markers {
+		x, y := skyPlotPoint(marker.point.Azimuth, marker.point.Elevation)
+		text := fmt.Sprintf("%s %s", marker.label, time.Unix(marker.point.Timestamp, 0).UTC().Format("15:04:05"))
+		if marker.label == "TCA" {
+			text += fmt.Sprintf(" %.0f°", marker.point.Elevation)
+		}
+
+		// Labels point away from the center so they stay inside the plot.
+		anchor, dx := "start", 9.0
+		if x > SKY_PLOT_CENTER {
+			anchor, dx = "end", -9.0
+		}
+		fmt.Fprintf(&b, `<circle cx="%.1f" cy="%.1f" r="5" fill="%s" stroke="#ffffff" stroke-width="1.5"/>`+"\n", x, y, marker.color)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" font-size="12" text-anchor="%s" dominant-baseline="central" fill="%s">%s</text>`+"\n",
+			x+dx, y, anchor, marker.color, text)
+	}
+
+	// Caption and legend.
+	visibility := "not visible"
+	if pass.Visible {
+		visibility = "visible"
+	}
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="14" text-anchor="middle" fill="#222222">%s UTC · max %.0f° · %d min %02d s · %s</text>`+"\n",
+		SKY_PLOT_CENTER, SKY_PLOT_SIZE+8, aos.Format("2 Jan 2006 15:04"), pass.TCA.Elevation,
+		pass.DurationSeconds/60, pass.DurationSeconds%60, visibility)
+	legendY := SKY_PLOT_SIZE + 34
+	fmt.Fprintf(&b, `<path d="M%d %dh28" stroke="#e8a317" stroke-width="3.5"/>`+"\n", SKY_PLOT_CENTER-130, legendY)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="12" dominant-baseline="central" fill="#333333">sunlit</text>`+"\n", SKY_PLOT_CENTER-96, legendY)
+	fmt.Fprintf(&b, `<path d="M%d %dh28" stroke="#5b6472" stroke-width="2.5" stroke-dasharray="5 4"/>`+"\n", SKY_PLOT_CENTER+10, legendY)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="12" dominant-baseline="central" fill="#333333">in Earth's shadow</text>`+"\n", SKY_PLOT_CENTER+44, legendY)
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+const (
+	SNAPSHOT_DEFAULT_WIDTH = 1024
+	SNAPSHOT_MIN_WIDTH     = 256
+	SNAPSHOT_MAX_WIDTH     = 2048
+	SNAPSHOT_MIN_HEIGHT    = 128
+	SNAPSHOT_MAX_HEIGHT    = 1024
+	SNAPSHOT_TRACK_SECONDS = 90 * 60 // about one orbit
+
+	// Open Graph images are shown at 1.91:1.
+	CARD_WIDTH  = 1200
+	CARD_HEIGHT = 630
+)
+
+var ErrInvalidSnapshot = errors.New("invalid snapshot size")
+
+var (
+	footprintColor = color.NRGBA{0xff, 0xd5, 0x4f, 0x30}
+	footprintEdge  = color.NRGBA{0xff, 0xd5, 0x4f, 0xc0}
+	trackColor     = color.NRGBA{0xff, 0x8a, 0x3d, 0xe0}
+	haloColor      = color.NRGBA{0x00, 0x00, 0x00, 0x70}
+	trussColor     = color.NRGBA{0xf2, 0xf2, 0xf2, 0xff}
+	panelColor     = color.NRGBA{0xe8, 0xa8, 0x38, 0xff}
+	moduleColor    = color.NRGBA{0xc8, 0xcc, 0xd4, 0xff}
+)
+
+var (
+	boldFont    = mustParseFont(gobold.TTF)
+	regularFont = mustParseFont(goregular.TTF)
+)
+
+func mustParseFont(ttf []byte) *opentype.Font {
+	f, err := opentype.Parse(ttf)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded font: %v", err))
+	}
+	return f
+}
+
+// snapshotScene is everything a map snapshot shows at one instant.
+type snapshotScene struct {
+	time            time.Time
+	latitude        float64
+	longitude       float64
+	altitude        float64
+	track           models.Coordinates
+	sunLat, sunLon  float64
+	footprintRadius float64 // degrees of arc
+}
+
+func (s *ISSService) snapshotScene(timestamp int64) (*snapshotScene, error) {
+	if timestamp == 0 {
+		timestamp = s.Now().Unix()
+	}
+
+	state, err := s.stateAt(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ISS state: %w", err)
+	}
+
+	positions, err := s.GetPositionsInRange(timestamp-SNAPSHOT_TRACK_SECONDS, timestamp, "kilometers")
+	if err != nil {
+		return nil, err
+	}
+
+	track := make(models.Coordinates, 0, len(positions)+1)
+	for _, position := range positions {
+		track = append(track, [2]float64{position.Longitude, position.Latitude})
+	}
+	track = append(track, [2]float64{state.Longitude, state.Latitude})
+
+	t := time.Unix(timestamp, 0).UTC()
+	sunLat, sunLon := solarSubpoint(t)
+
+	return &snapshotScene{
+		time:      t,
+		latitude:  state.Latitude,
+		longitude: state.Longitude,
+		altitude:  state.Altitude,
+		track:     unwrapLongitudes(track),
+		sunLat:    sunLat,
+		sunLon:    sunLon,
+		// The footprint is where the ISS is above the horizon.
+		footprintRadius: toDegrees(math.Acos(EARTH_RADIUS_KM / (EARTH_RADIUS_KM + state.Altitude))),
+	}, nil
+}
+
+// draw renders the scene onto a world map. Line widths and the icon scale
+// with the canvas so small thumbnails stay legible.
+func (sc *snapshotScene) draw(c *mapCanvas) {
+	scale := math.Max(0.5, float64(c.width)/SNAPSHOT_DEFAULT_WIDTH)
+
+	c.drawBaseMap()
+	c.shadeNight(sc.sunLat, sc.sunLon)
+
+	c.fillCap(sc.latitude, sc.longitude, sc.footprintRadius, footprintColor)
+	edge := c.newStroke()
+	c.strokePath(edge, capRing(sc.latitude, sc.longitude, sc.footprintRadius), 1.5*scale)
+	c.paintStroke(edge, footprintEdge)
+
+	track := c.newStroke()
+	c.strokePath(track, sc.track, 2.5*scale)
+	c.paintStroke(track, trackColor)
+
+	x, y := c.project(sc.latitude, sc.longitude)
+	for _, shift := range []float64{-1, 0, 1} {
+		drawISSIcon(c, x+shift*float64(c.width), y, scale)
+	}
+}
+
+// drawISSIcon draws a top view of the station centered on x, y: the truss
+// with its four pairs of solar arrays across the pressurized modules.
+func drawISSIcon(c *mapCanvas, x, y, scale float64) {
+	u := 1.2 * scale
+
+	c.fillDisc(x, y, 15*u, haloColor)
+	c.fillRect(x-2*u, y-7*u, x+2*u, y+7*u, moduleColor)
+	for _, side := range []float64{-1, 1} {
+		for _, offset := range []float64{7, 11.5} {
+			px := x + side*offset*u
+			c.fillRect(px-1.8*u, y-9*u, px+1.8*u, y-1.5*u, panelColor)
+			c.fillRect(px-1.8*u, y+1.5*u, px+1.8*u, y+9*u, panelColor)
+		}
+	}
+	c.fillRect(x-13.5*u, y-0.8*u, x+13.5*u, y+0.8*u, trussColor)
+}
+
+// RenderSnapshot draws the ISS at timestamp (0 means now) on an
+// equirectangular world map with its footprint, the last orbit of stored
+// ground track and the day/night shading, and encodes it as PNG. The map
+// fills the whole image, so sizes other than 2:1 stretch it.
+func (s *ISSService) RenderSnapshot(timestamp int64, width, height int) ([]byte, error) {
+	if width < SNAPSHOT_MIN_WIDTH || width > SNAPSHOT_MAX_WIDTH {
+		return nil, fmt.Errorf("%w: width must be between %d and %d", ErrInvalidSnapshot, SNAPSHOT_MIN_WIDTH, SNAPSHOT_MAX_WIDTH)
+	}
+	if height < SNAPSHOT_MIN_HEIGHT || height > SNAPSHOT_MAX_HEIGHT {
+		return nil, fmt.Errorf("%w: height must be between %d and %d", ErrInvalidSnapshot, SNAPSHOT_MIN_HEIGHT, SNAPSHOT_MAX_HEIGHT)
+	}
+
+	scene, err := s.snapshotScene(timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	canvas := newMapCanvas(width, height)
+	scene.draw(canvas)
+
+	return encodePNG(canvas.img)
+}
+
+// RenderSocialCard draws a CARD_WIDTH x CARD_HEIGHT Open Graph image of
+// the ISS at timestamp (0 means now) and encodes it as PNG.
+func (s *ISSService) RenderSocialCard(timestamp int64) ([]byte, error) {
+	scene, err := s.snapshotScene(timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := scene.drawCard()
+	if err != nil {
+		return nil, err
+	}
+
+	return encodePNG(card)
+}
+
+// drawCard lays out the social card: the snapshot map turned so the ISS is
+// in the middle, with a caption naming the region below it, its
+// coordinates, altitude and the time.
+func (sc *snapshotScene) drawCard() (*image.NRGBA, error) {
+	// Render a full 2:1 world at the card's height, then crop it around the
+	// ISS, wrapping across the antimeridian.
+	canvas := newMapCanvas(2*CARD_HEIGHT, CARD_HEIGHT)
+	sc.draw(canvas)
+	issX, _ := canvas.project(sc.latitude, sc.longitude)
+	offset := int(math.Round(issX)) - CARD_WIDTH/2
+
+	card := image.NewNRGBA(image.Rect(0, 0, CARD_WIDTH, CARD_HEIGHT))
+	for x := range CARD_WIDTH {
+		srcX := ((x+offset)%canvas.width + canvas.width) % canvas.width
+		draw.Draw(card, image.Rect(x, 0, x+1, CARD_HEIGHT), canvas.img, image.Pt(srcX, 0), draw.Src)
+	}
+
+	// Darken the bottom so the caption reads on any background.
+	const bandHeight = 190
+	for y := CARD_HEIGHT - bandHeight; y < CARD_HEIGHT; y++ {
+		opacity := 0.85 * float64(y-(CARD_HEIGHT-bandHeight)) / bandHeight
+		draw.DrawMask(card, image.Rect(0, y, CARD_WIDTH, y+1), image.NewUniform(color.Black), image.Point{},
+			image.NewUniform(color.Alpha{uint8(opacity * 255)}), image.Point{}, draw.Over)
+	}
+
+	title := cardTitle(sc.latitude, sc.longitude)
+	details := fmt.Sprintf("%s · %s · %.0f km up · %s",
+		formatLatitude(sc.latitude), formatLongitude(sc.longitude), sc.altitude,
+		sc.time.Format("2 Jan 2006 15:04 UTC"))
+
+	if err := drawText(card, boldFont, 52, 56, CARD_HEIGHT-96, color.White, title); err != nil {
+		return nil, err
+	}
+	if err := drawText(card, regularFont, 28, 58, CARD_HEIGHT-48, color.NRGBA{0xdd, 0xe3, 0xea, 0xff}, details); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+// cardTitle names the region below the ISS for the social card caption.
+func cardTitle(lat, lon float64) string {
+	region := ReverseGeocode(lat, lon)
+	if region.Type != models.RegionTypeCountry {
+		return "The ISS is over the " + region.Name
+	}
+	return "The ISS is over " + region.Name
+}
+
+func drawText(img draw.Image, f *opentype.Font, size float64, x, y int, col color.Color, text string) error {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return fmt.Errorf("failed to load font: %w", err)
+	}
+	defer face.Close()
+
+	drawer := font.Drawer{Dst: img, Src: image.NewUniform(col), Face: face, Dot: fixed.P(x, y)}
+	drawer.DrawString(text)
+	return nil
+}
+
+func formatLatitude(lat float64) string {
+	if lat < 0 {
+		return fmt.Sprintf("%.2f°S", -lat)
+	}
+	return fmt.Sprintf("%.2f°N", lat)
+}
+
+func formatLongitude(lon float64) string {
+	if lon < 0 {
+		return fmt.Sprintf("%.2f°W", -lon)
+	}
+	return fmt.Sprintf("%.2f°E", lon)
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestBaseMap(t *testing.T) {
+	c := newMapCanvas(720, 360)
+	c.drawBaseMap()
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     color.NRGBA
+	}{
+		{"Sahara", 23, 10, landColor},
+		{"Siberia", 62, 100, landColor},
+		{"Australia", -25, 134, landColor},
+		{"Amazon", -5, -62, landColor},
+		{"Antarctica", -80, 170, landColor},
+		{"Pacific", 2, -155, oceanColor},
+		{"Atlantic", 35, -40, oceanColor},
+		{"Caspian Sea", 42, 50.5, oceanColor},
+		{"Black Sea", 43.5, 34, oceanColor},
+		{"Hudson Bay", 59, -86, oceanColor},
+	}
+
+	for _, tt := range tests {
+		x, y := c.project(tt.lat, tt.lon)
+		if got := c.img.NRGBAAt(int(x), int(y)); got != tt.want {
+			t.Errorf("%s: pixel %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCardTitle(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		want     string
+	}{
+		{52.0, 20.0, "The ISS is over Poland"},
+		{35.7, 144.5, "The ISS is over the Pacific Ocean"}, // off Honshu, not Japan
+		{55.0, 5.0, "The ISS is over the Atlantic Ocean"},  // North Sea, not the Netherlands
+		{43.0, 34.0, "The ISS is over the Black Sea"},
+	}
+
+	for _, tt := range tests {
+		if got := cardTitle(tt.lat, tt.lon); got != tt.want {
+			t.Errorf("cardTitle(%v, %v) = %q, want %q", tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"math"
+	"sort"
+)
+
+// spatialIndex is a k-d tree over points on the unit sphere. Working with
+// unit vectors instead of latitude/longitude avoids special cases at the
+// poles and the antimeridian: the straight-line (chord) distance between
+// two unit vectors grows monotonically with their great-circle distance.
+type spatialIndex struct {
+	root *kdNode
+}
+
+type kdNode struct {
+	point       vec3
+	item        int
+	axis        int
+	left, right *kdNode
+}
+
+// newSpatialIndex indexes n points whose latitude and longitude are given
+// by coordinates; queries return the point numbers.
+func newSpatialIndex(n int, coordinates func(i int) (lat, lon float64)) *spatialIndex {
+	items := make([]int, n)
+	points := make([]vec3, n)
+	for i := range n {
+		items[i] = i
+		points[i] = subpointDirection(coordinates(i))
+	}
+	return &spatialIndex{root: buildKDNode(points, items, 0)}
+}
+
+func buildKDNode(points []vec3, items []int, depth int) *kdNode {
+	if len(items) == 0 {
+		return nil
+	}
+
+	axis := depth % 3
+	sort.Slice(items, func(i, j int) bool {
+		return points[items[i]][axis] < points[items[j]][axis]
+	})
+
+	mid := len(items) / 2
+	return &kdNode{
+		point: points[items[mid]],
+		item:  items[mid],
+		axis:  axis,
+		left:  buildKDNode(points, items[:mid], depth+1),
+		right: buildKDNode(points, items[mid+1:], depth+1),
+	}
+}
+
+// nearest returns the item closest to lat/lon, or -1 for an empty index.
+func (idx *spatialIndex) nearest(lat, lon float64) int {
+	query := subpointDirection(lat, lon)
+	best, bestDistSq := -1, math.Inf(1)
+
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+
+		if d := n.point.sub(query); d.dot(d) < bestDistSq {
+			best, bestDistSq = n.item, d.dot(d)
+		}
+
+		diff := query[n.axis] - n.point[n.axis]
+		near, far := n.left, n.right
+		if diff > 0 {
+			near, far = far, near
+		}
+
+		search(near)
+		if diff*diff < bestDistSq {
+			search(far)
+		}
+	}
+	search(idx.root)
+
+	return best
+}
+
+// within calls visit for every item within radiusKm of lat/lon along the
+// surface.
+func (idx *spatialIndex) within(lat, lon, radiusKm float64, visit func(item int)) {
+	query := subpointDirection(lat, lon)
+	chord := 2 * math.Sin(math.Min(radiusKm/EARTH_RADIUS_KM, math.Pi)/2)
+	chordSq := chord * chord
+
+	var search func(n *kdNode)
+	search = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+
+		if d := n.point.sub(query); d.dot(d) <= chordSq {
+			visit(n.item)
+		}
+
+		diff := query[n.axis] - n.point[n.axis]
+		if diff <= chord {
+			search(n.left)
+		}
+		if diff >= -chord {
+			search(n.right)
+		}
+	}
+	search(idx.root)
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	TERMINATOR_STEP_DEG = 1.0
+
+	SUN_ELEVATION_TERMINATOR   = 0.0
+	SUN_ELEVATION_CIVIL        = -6.0
+	SUN_ELEVATION_NAUTICAL     = -12.0
+	SUN_ELEVATION_ASTRONOMICAL = -18.0
+)
+
+// solarSubpoint returns the point where the Sun is at the zenith, using the
+// low-precision solar coordinates of the Astronomical Almanac (about 0.01°).
+// It is the only solar position computed here: the terminator, passes,
+// snapshots, attitude and interpolated samples all use it. It agrees with
+// the SolarLat/SolarLon that wheretheiss.at stores with each position,
+// except that longitude is wrapped to [-180, 180) rather than [0, 360).
+func solarSubpoint(t time.Time) (lat, lon float64) {
+	n := julianDateTT(t) - JD_J2000
+
+	meanLon := toRadians(280.460 + 0.9856474*n)
+	meanAnomaly := toRadians(357.528 + 0.9856003*n)
+	eclipticLon := meanLon + toRadians(1.915*math.Sin(meanAnomaly)+0.020*math.Sin(2*meanAnomaly))
+	obliquity := toRadians(23.439 - 0.0000004*n)
+
+	rightAscension := math.Atan2(math.Cos(obliquity)*math.Sin(eclipticLon), math.Cos(eclipticLon))
+	declination := math.Asin(math.Sin(obliquity) * math.Sin(eclipticLon))
+
+	lon = normalizeDegrees(toDegrees(rightAscension-gmstRadians(t)), 180)
+	return toDegrees(declination), lon
+}
+
+// GetTerminator returns the solar subpoint, the civil, nautical and
+// astronomical twilight bands as rings between consecutive Sun elevation
+// limits, and the night region where the Sun is below -18°.
+func GetTerminator(t time.Time) *models.GeoJSONFeatureCollection {
+	sunLat, sunLon := solarSubpoint(t)
+
+	features := []models.GeoJSONFeature{
+		models.NewGeoJSONFeature(models.GeoJSONPoint, [2]float64{sunLon, sunLat}, map[string]any{
+			"name":      "solar_subpoint",
+			"timestamp": t.Unix(),
+		}),
+	}
+
+	bands := []struct {
+		name         string
+		upper, lower float64
+	}{
+		{"civil_twilight", SUN_ELEVATION_TERMINATOR, SUN_ELEVATION_CIVIL},
+		{"nautical_twilight", SUN_ELEVATION_CIVIL, SUN_ELEVATION_NAUTICAL},
+		{"astronomical_twilight", SUN_ELEVATION_NAUTICAL, SUN_ELEVATION_ASTRONOMICAL},
+	}
+
+	for _, band := range bands {
+		geometryType, coordinates := twilightBand(sunLat, sunLon, band.upper, band.lower)
+		features = append(features, models.NewGeoJSONFeature(geometryType, coordinates, map[string]any{
+			"name":              band.name,
+			"sun_elevation_max": band.upper,
+			"sun_elevation_min": band.lower,
+		}))
+	}
+
+	geometryType, coordinates := darkRegion(sunLat, sunLon, SUN_ELEVATION_ASTRONOMICAL)
+	features = append(features, models.NewGeoJSONFeature(geometryType, coordinates, map[string]any{
+		"name":              "night",
+		"sun_elevation_max": SUN_ELEVATION_ASTRONOMICAL,
+	}))
+
+	return models.NewGeoJSONFeatureCollection(features)
+}
+
+// darkRegion returns the area where the Sun is below elevation degrees: a
+// spherical cap of radius 90°+elevation around the anti-solar point.
+func darkRegion(sunLat, sunLon, elevation float64) (string, any) {
+	polygons := [][]models.Coordinates{}
+	for _, ring := range darkRings(sunLat, sunLon, elevation) {
+		polygons = append(polygons, []models.Coordinates{ring})
+	}
+	return polygonGeometry(polygons)
+}
+
+// twilightBand returns the area where the Sun is between lower and upper
+// degrees of elevation: the dark region below upper with the one below
+// lower cut out as a hole. When both contain the same pole the band is a
+// single ring between their edges.
+func twilightBand(sunLat, sunLon, upper, lower float64) (string, any) {
+	centerLat, centerLon := -sunLat, normalizeDegrees(sunLon+180, 180)
+	outerRadius, innerRadius := 90+upper, 90+lower
+
+	if pole := capPole(centerLat, outerRadius); pole != 0 && capPole(centerLat, innerRadius) == pole {
+		outer := polarCapEdge(centerLat, centerLon, outerRadius)
+		inner := polarCapEdge(centerLat, centerLon, innerRadius)
+		ring := append(outer, reversed(inner)...)
+		return models.GeoJSONPolygon, []models.Coordinates{orient(append(ring, ring[0]), true)}
+	}
+
+	var polygons [][]models.Coordinates
+	for _, ring := range darkRings(sunLat, sunLon, upper) {
+		polygons = append(polygons, []models.Coordinates{orient(ring, true)})
+	}
+
+	for _, hole := range darkRings(sunLat, sunLon, lower) {
+		for i, polygon := range polygons {
+			if ringInside(hole, polygon[0]) {
+				polygons[i] = append(polygon, orient(hole, false))
+				break
+			}
+		}
+	}
+
+	return polygonGeometry(polygons)
+}
+
+// darkRings returns the outline of the region where the Sun is below
+// elevation degrees, split at the antimeridian into at most two rings.
+func darkRings(sunLat, sunLon, elevation float64) []models.Coordinates {
+	centerLat := -sunLat
+	centerLon := normalizeDegrees(sunLon+180, 180)
+	radius := 90 + elevation
+
+	if pole := capPole(centerLat, radius); pole != 0 {
+		return []models.Coordinates{polarCapRing(centerLat, centerLon, radius, pole)}
+	}
+
+	ring := capRing(centerLat, centerLon, radius)
+	west := clipLongitude(ring, -540, 180)
+	east := clipLongitude(ring, 180, 540)
+	if len(east) == 0 {
+		west = clipLongitude(ring, -180, 540)
+		east = clipLongitude(ring, -540, -180)
+		shiftLongitude(east, 360)
+	} else {
+		shiftLongitude(east, -360)
+	}
+
+	if len(east) == 0 {
+		return []models.Coordinates{west}
+	}
+	return []models.Coordinates{west, east}
+}
+
+// capPole returns the latitude of the pole a cap contains, or 0 when it
+// contains neither.
+func capPole(centerLat, radius float64) float64 {
+	switch {
+	case centerLat+radius >= 90:
+		return 90
+	case centerLat-radius <= -90:
+		return -90
+	}
+	return 0
+}
+
+func polygonGeometry(polygons [][]models.Coordinates) (string, any) {
+	if len(polygons) == 1 {
+		return models.GeoJSONPolygon, polygons[0]
+	}
+	return models.GeoJSONMultiPolygon, polygons
+}
+
+// capRing traces a small circle around a center that keeps clear of the
+// poles. Longitudes are continuous from the center, so they may leave
+// [-180, 180] until clipped.
+func capRing(centerLat, centerLon, radius float64) models.Coordinates {
+	latRad, radiusRad := toRadians(centerLat), toRadians(radius)
+
+	var ring models.Coordinates
+	for bearing := 0.0; bearing < 360; bearing += TERMINATOR_STEP_DEG {
+		b := toRadians(bearing)
+		lat := math.Asin(math.Sin(latRad)*math.Cos(radiusRad) + math.Cos(latRad)*math.Sin(radiusRad)*math.Cos(b))
+		dLon := math.Atan2(math.Sin(b)*math.Sin(radiusRad)*math.Cos(latRad), math.Cos(radiusRad)-math.Sin(latRad)*math.Sin(lat))
+		ring = append(ring, [2]float64{centerLon + toDegrees(dLon), toDegrees(lat)})
+	}
+	return append(ring, ring[0])
+}
+
+// polarCapRing traces a cap that contains the pole at poleLat: its edge,
+// closed along the pole.
+func polarCapRing(centerLat, centerLon, radius, poleLat float64) models.Coordinates {
+	ring := append(polarCapEdge(centerLat, centerLon, radius), [2]float64{180, poleLat}, [2]float64{-180, poleLat})
+	return append(ring, ring[0])
+}
+
+// polarCapEdge traces the edge of a cap that contains a pole from -180° to
+// 180° of longitude. Every meridian crosses it once, so the edge is solved
+// per longitude.
+func polarCapEdge(centerLat, centerLon, radius float64) models.Coordinates {
+	latRad := toRadians(centerLat)
+	cosRadius := math.Cos(toRadians(radius))
+
+	var edge models.Coordinates
+	for lon := -180.0; lon <= 180; lon += TERMINATOR_STEP_DEG {
+		// sin(lat)·a + cos(lat)·b = cos(radius), written as R·sin(lat + psi).
+		a := math.Sin(latRad)
+		b := math.Cos(latRad) * math.Cos(toRadians(lon-centerLon))
+		r := math.Hypot(a, b)
+		psi := math.Atan2(b, a)
+		x := math.Asin(math.Max(-1, math.Min(1, cosRadius/r)))
+
+		lat := normalizeDegrees(toDegrees(x-psi), 180)
+		if lat < -90 || lat > 90 {
+			lat = normalizeDegrees(toDegrees(math.Pi-x-psi), 180)
+		}
+		edge = append(edge, [2]float64{lon, math.Max(-90, math.Min(90, lat))})
+	}
+	return edge
+}
+
+// clipLongitude clips a closed ring to minLon <= lon <= maxLon
+// (Sutherland-Hodgman against two meridians). It returns nil when nothing
+// of the ring remains.
+func clipLongitude(ring models.Coordinates, minLon, maxLon float64) models.Coordinates {
+	clip := func(points models.Coordinates, inside func(p [2]float64) bool, edge float64) models.Coordinates {
+		var out models.Coordinates
+		for i := 0; i+1 < len(points); i++ {
+			p, q := points[i], points[i+1]
+			if inside(p) {
+				out = append(out, p)
+			}
+			if inside(p) != inside(q) {
+				t := (edge - p[0]) / (q[0] - p[0])
+				out = append(out, [2]float64{edge, p[1] + t*(q[1]-p[1])})
+			}
+		}
+		if len(out) > 0 {
+			out = append(out, out[0])
+		}
+		return out
+	}
+
+	out := clip(ring, func(p [2]float64) bool { return p[0] >= minLon }, minLon)
+	out = clip(out, func(p [2]float64) bool { return p[0] <= maxLon }, maxLon)
+	if len(out) < 4 {
+		return nil
+	}
+	return out
+}
+
+func shiftLongitude(ring models.Coordinates, delta float64) {
+	for i := range ring {
+		ring[i][0] += delta
+	}
+}
+
+// ringInside reports whether inner lies inside outer, judged by its first
+// vertex off the antimeridian, where the two may share an edge.
+func ringInside(inner, outer models.Coordinates) bool {
+	for _, p := range inner {
+		if math.Abs(p[0]) < 180 {
+			return rayCast(p[1], p[0], outer)
+		}
+	}
+	return false
+}
+
+// orient returns the ring wound counterclockwise, as GeoJSON wants for
+// outer rings, or clockwise for holes.
+func orient(ring models.Coordinates, counterclockwise bool) models.Coordinates {
+	area := 0.0
+	for i := 0; i+1 < len(ring); i++ {
+		area += ring[i][0]*ring[i+1][1] - ring[i+1][0]*ring[i][1]
+	}
+	if (area > 0) != counterclockwise {
+		return reversed(ring)
+	}
+	return ring
+}
+
+func reversed(ring models.Coordinates) models.Coordinates {
+	out := make(models.Coordinates, len(ring))
+	for i, p := range ring {
+		out[len(ring)-1-i] = p
+	}
+	return out
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestSolarSubpoint(t *testing.T) {
+	// March equinox 2024-03-20 03:06 UTC and June solstice 2024-06-20 20:51 UTC.
+	lat, _ := solarSubpoint(time.Date(2024, 3, 20, 3, 6, 0, 0, time.UTC))
+	if math.Abs(lat) > 0.02 {
+		t.Errorf("equinox declination %f, want 0", lat)
+	}
+
+	lat, lon := solarSubpoint(time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC))
+	if math.Abs(lat-23.44) > 0.02 {
+		t.Errorf("solstice declination %f, want 23.44", lat)
+	}
+	// Equation of time is about -1.5 minutes, so the Sun is still east of Greenwich.
+	if math.Abs(lon-0.4) > 0.1 {
+		t.Errorf("solstice noon subpoint longitude %f, want about 0.4", lon)
+	}
+}
+
+func TestDarkRegion(t *testing.T) {
+	for _, at := range []time.Time{
+		time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 21, 3, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 20, 18, 0, 0, 0, time.UTC),
+	} {
+		sunLat, sunLon := solarSubpoint(at)
+
+		for _, elevation := range []float64{SUN_ELEVATION_TERMINATOR, SUN_ELEVATION_ASTRONOMICAL} {
+			geometryType, coordinates := darkRegion(sunLat, sunLon, elevation)
+
+			var rings []models.Coordinates
+			switch geometryType {
+			case models.GeoJSONPolygon:
+				rings = coordinates.([]models.Coordinates)
+			case models.GeoJSONMultiPolygon:
+				for _, polygon := range coordinates.([][]models.Coordinates) {
+					rings = append(rings, polygon[0])
+				}
+			}
+
+			contains := func(lat, lon float64) bool {
+				for _, ring := range rings {
+					if rayCast(lat, lon, ring) {
+						return true
+					}
+				}
+				return false
+			}
+
+			antiLon := normalizeDegrees(sunLon+180, 180)
+			if !contains(-sunLat*0.99, antiLon) {
+				t.Errorf("%s, %v°: anti-solar point not dark", at, elevation)
+			}
+			if contains(sunLat*0.99, sunLon) {
+				t.Errorf("%s, %v°: subsolar point dark", at, elevation)
+			}
+		}
+	}
+}
+
+func TestTwilightBands(t *testing.T) {
+	bands := []struct{ upper, lower float64 }{
+		{SUN_ELEVATION_TERMINATOR, SUN_ELEVATION_CIVIL},
+		{SUN_ELEVATION_CIVIL, SUN_ELEVATION_NAUTICAL},
+		{SUN_ELEVATION_NAUTICAL, SUN_ELEVATION_ASTRONOMICAL},
+	}
+
+	for _, at := range []time.Time{
+		time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 21, 3, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 20, 18, 0, 0, 0, time.UTC),
+		time.Date(2024, 9, 1, 23, 30, 0, 0, time.UTC),
+	} {
+		sunLat, sunLon := solarSubpoint(at)
+
+		for _, band := range bands {
+			geometryType, coordinates := twilightBand(sunLat, sunLon, band.upper, band.lower)
+
+			var polygons [][]models.Coordinates
+			switch geometryType {
+			case models.GeoJSONPolygon:
+				polygons = [][]models.Coordinates{coordinates.([]models.Coordinates)}
+			case models.GeoJSONMultiPolygon:
+				polygons = coordinates.([][]models.Coordinates)
+			}
+
+			contains := func(lat, lon float64) bool {
+				for _, polygon := range polygons {
+					if !rayCast(lat, lon, polygon[0]) {
+						continue
+					}
+					inHole := false
+					for _, hole := range polygon[1:] {
+						inHole = inHole || rayCast(lat, lon, hole)
+					}
+					if !inHole {
+						return true
+					}
+				}
+				return false
+			}
+
+			// Sample a grid and compare with the Sun elevation at each point,
+			// keeping clear of the band edges, the poles and the antimeridian.
+			for lat := -85.0; lat <= 85; lat += 5 {
+				for lon := -177.5; lon < 180; lon += 5 {
+					elevation := 90 - toDegrees(haversineKm(lat, lon, sunLat, sunLon)/EARTH_RADIUS_KM)
+					if math.Abs(elevation-band.upper) < 1 || math.Abs(elevation-band.lower) < 1 {
+						continue
+					}
+
+					want := elevation < band.upper && elevation > band.lower
+					if got := contains(lat, lon); got != want {
+						t.Errorf("%s, band %v..%v°: (%v, %v) at %.1f° in band = %v, want %v",
+							at.Format(time.RFC3339), band.upper, band.lower, lat, lon, elevation, got, want)
+					}
+				}
+			}
+		}
+	}
+}
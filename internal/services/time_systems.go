@@ -0,0 +1,235 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	SECONDS_PER_DAY  = 86400.0
+	JD_UNIX_EPOCH    = 2440587.5
+	JD_J2000         = 2451545.0
+	MJD_OFFSET       = 2400000.5
+	TT_MINUS_TAI     = 32.184
+	TAI_MINUS_GPS    = 19.0
+	GPS_WEEK_SECONDS = 7 * SECONDS_PER_DAY
+	TAI_TT_FORMAT    = "2006-01-02T15:04:05.000"
+	TIME_SCALE_UTC   = "utc"
+	TIME_SCALE_UNIX  = "unix"
+	TIME_SCALE_TAI   = "tai"
+	TIME_SCALE_TT    = "tt"
+	TIME_SCALE_GPS   = "gps"
+	TIME_SCALE_JD    = "jd"
+	TIME_SCALE_MJD   = "mjd"
+	TIME_SCALE_JD_TT = "jd_tt"
+)
+
+var ErrInvalidTime = errors.New("invalid time")
+
+var gpsEpoch = time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC)
+
+// leapSeconds lists TAI-UTC from each effective date on. Update it when the
+// IERS announces a new leap second (Bulletin C).
+var leapSeconds = []struct {
+	from   time.Time
+	offset float64
+}{
+	{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+	{time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), 11},
+	{time.Date(1973, 1, 1, 0, 0, 0, 0, time.UTC), 12},
+	{time.Date(1974, 1, 1, 0, 0, 0, 0, time.UTC), 13},
+	{time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC), 14},
+	{time.Date(1976, 1, 1, 0, 0, 0, 0, time.UTC), 15},
+	{time.Date(1977, 1, 1, 0, 0, 0, 0, time.UTC), 16},
+	{time.Date(1978, 1, 1, 0, 0, 0, 0, time.UTC), 17},
+	{time.Date(1979, 1, 1, 0, 0, 0, 0, time.UTC), 18},
+	{time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), 19},
+	{time.Date(1981, 7, 1, 0, 0, 0, 0, time.UTC), 20},
+	{time.Date(1982, 7, 1, 0, 0, 0, 0, time.UTC), 21},
+	{time.Date(1983, 7, 1, 0, 0, 0, 0, time.UTC), 22},
+	{time.Date(1985, 7, 1, 0, 0, 0, 0, time.UTC), 23},
+	{time.Date(1988, 1, 1, 0, 0, 0, 0, time.UTC), 24},
+	{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), 25},
+	{time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC), 26},
+	{time.Date(1992, 7, 1, 0, 0, 0, 0, time.UTC), 27},
+	{time.Date(1993, 7, 1, 0, 0, 0, 0, time.UTC), 28},
+	{time.Date(1994, 7, 1, 0, 0, 0, 0, time.UTC), 29},
+	{time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC), 30},
+	{time.Date(1997, 7, 1, 0, 0, 0, 0, time.UTC), 31},
+	{time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), 32},
+	{time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC), 33},
+	{time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC), 34},
+	{time.Date(2012, 7, 1, 0, 0, 0, 0, time.UTC), 35},
+	{time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC), 36},
+	{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+}
+
+// taiMinusUTC returns the leap second offset in effect at t. Dates before
+// 1972 use the initial 10 s offset.
+func taiMinusUTC(t time.Time) float64 {
+	offset := leapSeconds[0].offset
+	for _, entry := range leapSeconds {
+		if t.Before(entry.from) {
+			break
+		}
+		offset = entry.offset
+	}
+	return offset
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
+
+func fromUnixSeconds(seconds float64) time.Time {
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(math.Round(frac*1e9))).UTC()
+}
+
+// julianDateUTC returns the Julian date of t on the UTC scale, which is also
+// used as UT1 (|UT1-UTC| < 0.9 s).
+func julianDateUTC(t time.Time) float64 {
+	return unixSeconds(t)/SECONDS_PER_DAY + JD_UNIX_EPOCH
+}
+
+func julianDateTT(t time.Time) float64 {
+	return julianDateUTC(t) + (taiMinusUTC(t)+TT_MINUS_TAI)/SECONDS_PER_DAY
+}
+
+// julianCenturiesTT returns Julian centuries of TT since J2000.0, the time
+// argument of the precession and nutation series.
+func julianCenturiesTT(t time.Time) float64 {
+	return (julianDateTT(t) - JD_J2000) / 36525
+}
+
+// gmstRadians returns Greenwich Mean Sidereal Time (IAU 1982) at t.
+func gmstRadians(t time.Time) float64 {
+	tu := (julianDateUTC(t) - JD_J2000) / 36525
+
+	seconds := 67310.54841 +
+		(876600*3600+8640184.812866)*tu +
+		0.093104*tu*tu -
+		6.2e-6*tu*tu*tu
+
+	seconds = math.Mod(seconds, SECONDS_PER_DAY)
+	if seconds < 0 {
+		seconds += SECONDS_PER_DAY
+	}
+
+	return seconds / SECONDS_PER_DAY * 2 * math.Pi
+}
+
+// gastRadians returns Greenwich Apparent Sidereal Time: GMST plus the
+// equation of the equinoxes.
+func gastRadians(t time.Time) float64 {
+	dpsi, _, meanEps := nutation(julianCenturiesTT(t))
+	return math.Mod(gmstRadians(t)+dpsi*math.Cos(meanEps)+2*math.Pi, 2*math.Pi)
+}
+
+// ParseTime reads value on the given scale and returns the UTC instant. An
+// empty value means now.
+func ParseTime(value, scale string) (time.Time, error) {
+	scale = strings.ToLower(scale)
+	if scale == "" {
+		scale = TIME_SCALE_UTC
+	}
+
+	if value == "" {
+		return time.Now().UTC(), nil
+	}
+
+	switch scale {
+	case TIME_SCALE_UTC:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return t.UTC(), nil
+		}
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			return fromUnixSeconds(seconds), nil
+		}
+		return time.Time{}, fmt.Errorf("%w: utc expects RFC 3339 or Unix seconds", ErrInvalidTime)
+	case TIME_SCALE_UNIX:
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: unix expects seconds", ErrInvalidTime)
+		}
+		return fromUnixSeconds(seconds), nil
+	case TIME_SCALE_TAI, TIME_SCALE_TT:
+		t, err := time.Parse("2006-01-02T15:04:05.999999999", strings.TrimSuffix(value, "Z"))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s expects YYYY-MM-DDThh:mm:ss[.fff]", ErrInvalidTime, scale)
+		}
+		shift := 0.0
+		if scale == TIME_SCALE_TT {
+			shift = TT_MINUS_TAI
+		}
+		return fromAtomic(unixSeconds(t) - shift), nil
+	case TIME_SCALE_GPS:
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: gps expects seconds since 1980-01-06", ErrInvalidTime)
+		}
+		return fromAtomic(unixSeconds(gpsEpoch) + seconds + TAI_MINUS_GPS), nil
+	case TIME_SCALE_JD, TIME_SCALE_MJD, TIME_SCALE_JD_TT:
+		days, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %s expects a number of days", ErrInvalidTime, scale)
+		}
+		if scale == TIME_SCALE_MJD {
+			days += MJD_OFFSET
+		}
+		seconds := (days - JD_UNIX_EPOCH) * SECONDS_PER_DAY
+		if scale == TIME_SCALE_JD_TT {
+			return fromAtomic(seconds - TT_MINUS_TAI), nil
+		}
+		return fromUnixSeconds(seconds), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: unknown scale %q", ErrInvalidTime, scale)
+}
+
+// fromAtomic converts TAI, expressed as seconds on a Unix-like count, to
+// UTC. The leap second offset is looked up twice so instants right after a
+// leap second resolve correctly.
+func fromAtomic(taiSeconds float64) time.Time {
+	utc := fromUnixSeconds(taiSeconds - taiMinusUTC(fromUnixSeconds(taiSeconds)))
+	return fromUnixSeconds(taiSeconds - taiMinusUTC(utc))
+}
+
+// ConvertTime expresses a UTC instant on every supported time scale.
+func ConvertTime(t time.Time) *models.TimeConversionResponse {
+	t = t.UTC()
+	leap := taiMinusUTC(t)
+	unix := unixSeconds(t)
+
+	tai := fromUnixSeconds(unix + leap)
+	tt := fromUnixSeconds(unix + leap + TT_MINUS_TAI)
+
+	// GPS runs TAI-19 s, which matched UTC at the GPS epoch.
+	gpsSeconds := unix + leap - TAI_MINUS_GPS - unixSeconds(gpsEpoch)
+	gpsWeek := math.Floor(gpsSeconds / GPS_WEEK_SECONDS)
+
+	jdUTC := julianDateUTC(t)
+
+	return &models.TimeConversionResponse{
+		UTC:               t.Format(time.RFC3339Nano),
+		Unix:              unix,
+		TAI:               tai.Format(TAI_TT_FORMAT),
+		TT:                tt.Format(TAI_TT_FORMAT),
+		TAIMinusUTC:       leap,
+		GPSSeconds:        gpsSeconds,
+		GPSWeek:           int(gpsWeek),
+		GPSSecondsOfWeek:  gpsSeconds - gpsWeek*GPS_WEEK_SECONDS,
+		JulianDateUTC:     jdUTC,
+		JulianDateTT:      julianDateTT(t),
+		ModifiedJulianUTC: jdUTC - MJD_OFFSET,
+		JulianCenturiesTT: julianCenturiesTT(t),
+		GMSTDegrees:       toDegrees(gmstRadians(t)),
+		GASTDegrees:       toDegrees(gastRadians(t)),
+	}
+}
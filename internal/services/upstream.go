@@ -0,0 +1,402 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+const (
+	UPSTREAM_MAX_RETRIES = 2
+	UPSTREAM_RETRY_BASE  = 500 * time.Millisecond
+	// UPSTREAM_MAX_WAIT bounds how long a call queues for a rate limit token
+	// or honours a Retry-After before giving up.
+	UPSTREAM_MAX_WAIT          = 10 * time.Second
+	UPSTREAM_BREAKER_THRESHOLD = 5 // consecutive failures that open the circuit
+	UPSTREAM_BREAKER_COOLDOWN  = 30 * time.Second
+	UPSTREAM_CACHE_ENTRIES     = 2000
+	UPSTREAM_DEFAULT_RATE      = 5.0
+)
+
+// upstreamRates are the requests per second each host tolerates.
+var upstreamRates = map[string]float64{
+	"api.wheretheiss.at":  1,
+	"api.open-notify.org": 1,
+	"images-api.nasa.gov": 5,
+}
+
+var (
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrUpstreamThrottled   = errors.New("upstream rate limit exceeded")
+)
+
+// UpstreamStatusError is a non-200 response from an upstream API.
+type UpstreamStatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	retryAfter time.Duration
+}
+
+func (e *UpstreamStatusError) Error() string {
+	return fmt.Sprintf("%s - %s", e.Status, e.Body)
+}
+
+// transient reports whether a call failed in a way worth retrying, which
+// also counts against the host's circuit breaker.
+func transient(err error) bool {
+	var statusErr *UpstreamStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// UpstreamClient is the shared client for calls to third-party APIs. Each
+// host gets a token bucket and a circuit breaker, transient failures are
+// retried with jittered backoff, and responses can be cached for a TTL and
+// then revalidated with their ETag. A cached response is served stale when
+// the host fails.
+type UpstreamClient struct {
+	client *http.Client
+	// replaying skips rate limiting, as fixtures never reach the hosts.
+	replaying bool
+
+	mu    sync.Mutex
+	hosts map[string]*upstreamHost
+	cache map[string]*upstreamCacheEntry
+}
+
+type upstreamHost struct {
+	name    string
+	rate    float64
+	tokens  float64
+	updated time.Time
+
+	failures  int
+	state     string
+	openUntil time.Time
+	probing   bool
+
+	metrics models.UpstreamHostMetrics
+}
+
+type upstreamCacheEntry struct {
+	body         []byte
+	etag         string
+	lastModified string
+	expires      time.Time
+}
+
+// NewUpstreamClient returns a client that goes to the network, or records
+// or replays fixtures as fixtures.Mode says.
+func NewUpstreamClient(fixtures FixtureConfig) *UpstreamClient {
+	client := &UpstreamClient{
+		client:    &http.Client{Timeout: API_TIMEOUT},
+		replaying: fixtures.Mode == FIXTURE_MODE_REPLAY,
+		hosts:     make(map[string]*upstreamHost),
+		cache:     make(map[string]*upstreamCacheEntry),
+	}
+
+	if fixtures.Mode != "" {
+		client.client.Transport = NewFixtureTransport(fixtures, http.DefaultTransport)
+		log.Printf("Upstream API calls: %s fixtures in %s", fixtures.Mode, fixtures.Dir)
+	}
+
+	return client
+}
+
+// upstreamURL returns the URL in the environment variable name, or fallback
+// when it is unset. Pointing the URLs elsewhere lets fixtures be recorded
+// against, or development run with, a local stand-in.
+func upstreamURL(name, fallback string) string {
+	if value := strings.TrimRight(os.Getenv(name), "/"); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Get returns the body of a successful GET of rawURL. With a positive ttl
+// the response is cached; a zero ttl always asks upstream.
+func (c *UpstreamClient) Get(rawURL string, ttl time.Duration) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream URL: %w", err)
+	}
+
+	c.mu.Lock()
+	host := c.host(parsed.Host)
+	host.metrics.Requests++
+	entry := c.cache[rawURL]
+	if entry != nil && time.Now().Before(entry.expires) {
+		host.metrics.CacheHits++
+		c.mu.Unlock()
+		return entry.body, nil
+	}
+	c.mu.Unlock()
+
+	body, err := c.fetch(host, rawURL, entry, ttl)
+	if err != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if entry != nil {
+			host.metrics.StaleServed++
+			log.Printf("Serving stale response for %s: %v", rawURL, err)
+			return entry.body, nil
+		}
+		host.metrics.Failures++
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// Metrics returns the counters of every host called so far, by host name.
+func (c *UpstreamClient) Metrics() []models.UpstreamHostMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	metrics := make([]models.UpstreamHostMetrics, 0, len(c.hosts))
+	for _, host := range c.hosts {
+		m := host.metrics
+		m.BreakerState = host.state
+		metrics = append(metrics, m)
+	}
+	slices.SortFunc(metrics, func(a, b models.UpstreamHostMetrics) int { return strings.Compare(a.Host, b.Host) })
+
+	return metrics
+}
+
+// host returns the state of a host, creating it on first use. c.mu must be
+// held.
+func (c *UpstreamClient) host(name string) *upstreamHost {
+	if host, ok := c.hosts[name]; ok {
+		return host
+	}
+
+	rate, ok := upstreamRates[name]
+	if !ok {
+		rate = UPSTREAM_DEFAULT_RATE
+	}
+	host := &upstreamHost{
+		name:    name,
+		rate:    rate,
+		tokens:  max(1, rate),
+		updated: time.Now(),
+		state:   models.BreakerClosed,
+		metrics: models.UpstreamHostMetrics{Host: name, RatePerSecond: rate},
+	}
+	c.hosts[name] = host
+
+	return host
+}
+
+func (c *UpstreamClient) fetch(host *upstreamHost, rawURL string, entry *upstreamCacheEntry, ttl time.Duration) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= UPSTREAM_MAX_RETRIES; attempt++ {
+		if attempt > 0 {
+			delay := retryDelay(attempt, lastErr)
+			if delay > UPSTREAM_MAX_WAIT {
+				return nil, lastErr
+			}
+			c.mu.Lock()
+			host.metrics.Retries++
+			c.mu.Unlock()
+			time.Sleep(delay)
+		}
+
+		if err := c.admit(host); err != nil {
+			return nil, err
+		}
+
+		body, err := c.attempt(host, rawURL, entry, ttl)
+		c.record(host, err == nil || !transient(err))
+		if err == nil {
+			return body, nil
+		}
+		if !transient(err) {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, lastErr
+}
+
+// retryDelay is the exponential backoff before a retry, with jitter so
+// callers that failed together don't retry together. A Retry-After header
+// takes precedence.
+func retryDelay(attempt int, err error) time.Duration {
+	var statusErr *UpstreamStatusError
+	if errors.As(err, &statusErr) && statusErr.retryAfter > 0 {
+		return statusErr.retryAfter
+	}
+
+	delay := UPSTREAM_RETRY_BASE << (attempt - 1)
+	return delay/2 + rand.N(delay/2)
+}
+
+// admit checks the circuit breaker and waits for a rate limit token.
+func (c *UpstreamClient) admit(host *upstreamHost) error {
+	c.mu.Lock()
+	now := time.Now()
+
+	if host.state == models.BreakerOpen {
+		if now.Before(host.openUntil) {
+			host.metrics.BreakerRejections++
+			c.mu.Unlock()
+			return fmt.Errorf("%w: circuit open for %s until %s",
+				ErrUpstreamUnavailable, host.name, host.openUntil.UTC().Format(time.RFC3339))
+		}
+		host.state = models.BreakerHalfOpen
+	}
+	if host.state == models.BreakerHalfOpen {
+		if host.probing {
+			host.metrics.BreakerRejections++
+			c.mu.Unlock()
+			return fmt.Errorf("%w: %s is being probed after failures", ErrUpstreamUnavailable, host.name)
+		}
+		host.probing = true
+	}
+	if c.replaying {
+		c.mu.Unlock()
+		return nil
+	}
+
+	// Tokens may go negative: each caller reserves one and sleeps off the
+	// deficit, so queued calls leave at the host's rate.
+	host.tokens = min(max(1, host.rate), host.tokens+now.Sub(host.updated).Seconds()*host.rate)
+	host.updated = now
+	host.tokens--
+	wait := time.Duration(-host.tokens / host.rate * float64(time.Second))
+	if wait > UPSTREAM_MAX_WAIT {
+		host.tokens++
+		host.probing = false
+		host.metrics.RateLimited++
+		c.mu.Unlock()
+		return fmt.Errorf("%w: %s would need %s", ErrUpstreamThrottled, host.name, wait.Round(time.Second))
+	}
+	if wait > 0 {
+		host.metrics.ThrottledMs += wait.Milliseconds()
+	}
+	c.mu.Unlock()
+
+	time.Sleep(wait)
+	return nil
+}
+
+// record feeds the outcome of an attempt to the host's circuit breaker.
+func (c *UpstreamClient) record(host *upstreamHost, healthy bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	host.probing = false
+	if healthy {
+		host.failures = 0
+		host.state = models.BreakerClosed
+		return
+	}
+
+	host.failures++
+	if host.state == models.BreakerHalfOpen || host.failures >= UPSTREAM_BREAKER_THRESHOLD {
+		if host.state != models.BreakerOpen {
+			host.metrics.BreakerTrips++
+			log.Printf("Opening circuit for %s after %d failures", host.name, host.failures)
+		}
+		host.state = models.BreakerOpen
+		host.openUntil = time.Now().Add(UPSTREAM_BREAKER_COOLDOWN)
+	}
+}
+
+func (c *UpstreamClient) attempt(host *upstreamHost, rawURL string, entry *upstreamCacheEntry, ttl time.Duration) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil {
+		if entry.etag != "" {
+			req.Header.Set("If-None-Match", entry.etag)
+		}
+		if entry.lastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.lastModified)
+		}
+	}
+
+	c.mu.Lock()
+	host.metrics.Attempts++
+	c.mu.Unlock()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		c.mu.Lock()
+		host.metrics.Revalidations++
+		entry.expires = time.Now().Add(ttl)
+		c.mu.Unlock()
+		return entry.body, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		statusErr := &UpstreamStatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			statusErr.retryAfter = time.Duration(seconds) * time.Second
+		}
+		return nil, statusErr
+	}
+
+	if ttl > 0 {
+		c.store(rawURL, &upstreamCacheEntry{
+			body:         body,
+			etag:         resp.Header.Get("ETag"),
+			lastModified: resp.Header.Get("Last-Modified"),
+			expires:      time.Now().Add(ttl),
+		})
+	}
+
+	return body, nil
+}
+
+// store caches a response. When the cache is full, expired entries go
+// first, then the one closest to expiring.
+func (c *UpstreamClient) store(rawURL string, entry *upstreamCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.cache[rawURL]; !ok && len(c.cache) >= UPSTREAM_CACHE_ENTRIES {
+		now := time.Now()
+		var oldest string
+		for key, cached := range c.cache {
+			if now.After(cached.expires) {
+				delete(c.cache, key)
+			} else if oldest == "" || cached.expires.Before(c.cache[oldest].expires) {
+				oldest = key
+			}
+		}
+		if len(c.cache) >= UPSTREAM_CACHE_ENTRIES {
+			delete(c.cache, oldest)
+		}
+	}
+
+	c.cache[rawURL] = entry
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"iss-model-backend/internal/models"
+)
+
+func TestUpstreamClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case requests == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Header.Get("If-None-Match") == `"v1"`:
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte("crew"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewUpstreamClient(FixtureConfig{})
+	metrics := func() models.UpstreamHostMetrics { return client.Metrics()[0] }
+
+	body, err := client.Get(server.URL, time.Millisecond)
+	if err != nil || string(body) != "crew" {
+		t.Fatalf("Get after a 503 = %q, %v; want a retried success", body, err)
+	}
+	if m := metrics(); m.Attempts != 2 || m.Retries != 1 {
+		t.Errorf("attempts %d, retries %d; want 2 and 1", m.Attempts, m.Retries)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	if body, err := client.Get(server.URL, time.Hour); err != nil || string(body) != "crew" {
+		t.Fatalf("revalidating Get = %q, %v", body, err)
+	}
+	if body, err := client.Get(server.URL, time.Hour); err != nil || string(body) != "crew" {
+		t.Fatalf("cached Get = %q, %v", body, err)
+	}
+	if m := metrics(); m.Revalidations != 1 || m.CacheHits != 1 || m.Attempts != 3 {
+		t.Errorf("revalidations %d, cache hits %d, attempts %d; want 1, 1 and 3", m.Revalidations, m.CacheHits, m.Attempts)
+	}
+
+	host := client.hosts[metrics().Host]
+	for range UPSTREAM_BREAKER_THRESHOLD {
+		client.record(host, false)
+	}
+	if err := client.admit(host); !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("admit with an open circuit = %v, want ErrUpstreamUnavailable", err)
+	}
+
+	client.cache[server.URL].expires = time.Now()
+	if body, err := client.Get(server.URL, time.Hour); err != nil || string(body) != "crew" {
+		t.Errorf("Get with an open circuit = %q, %v; want the stale response", body, err)
+	}
+	if m := metrics(); m.BreakerState != models.BreakerOpen || m.StaleServed != 1 || m.Attempts != 3 {
+		t.Errorf("breaker %s, stale served %d, attempts %d; want open, 1 and 3", m.BreakerState, m.StaleServed, m.Attempts)
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"math"
+
+	"iss-model-backend/internal/models"
+)
+
+// vec3 is a Cartesian vector used by the frame and attitude math.
+type vec3 [3]float64
+
+func (a vec3) add(b vec3) vec3 {
+	return vec3{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func (a vec3) sub(b vec3) vec3 {
+	return vec3{a[0] - b[0], a[1] - b[1], a[2] - b[2]}
+}
+
+func (a vec3) scale(k float64) vec3 {
+	return vec3{a[0] * k, a[1] * k, a[2] * k}
+}
+
+func (a vec3) dot(b vec3) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func (a vec3) cross(b vec3) vec3 {
+	return vec3{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func (a vec3) norm() float64 {
+	return math.Sqrt(a.dot(a))
+}
+
+func (a vec3) unit() vec3 {
+	n := a.norm()
+	if n == 0 {
+		return a
+	}
+	return a.scale(1 / n)
+}
+
+func (a vec3) toModel() models.Vector3 {
+	return models.Vector3{X: a[0], Y: a[1], Z: a[2]}
+}
+
+// rotateAbout rotates v by angle radians about the unit axis (Rodrigues).
+func rotateAbout(v, axis vec3, angle float64) vec3 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return v.scale(c).add(axis.cross(v).scale(s)).add(axis.scale(axis.dot(v) * (1 - c)))
+}
+
+// mat3 is a row-major 3x3 matrix.
+type mat3 [3][3]float64
+
+func (m mat3) mulVec(v vec3) vec3 {
+	return vec3{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+func (m mat3) mul(n mat3) mat3 {
+	var out mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j] + m[i][2]*n[2][j]
+		}
+	}
+	return out
+}
+
+func (m mat3) transpose() mat3 {
+	var out mat3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out[i][j] = m[j][i]
+		}
+	}
+	return out
+}
+
+// rotX, rotY and rotZ are coordinate (passive) rotations by angle radians,
+// the ROT1/ROT2/ROT3 of Vallado's "Fundamentals of Astrodynamics".
+func rotX(angle float64) mat3 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return mat3{{1, 0, 0}, {0, c, s}, {0, -s, c}}
+}
+
+func rotY(angle float64) mat3 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return mat3{{c, 0, -s}, {0, 1, 0}, {s, 0, c}}
+}
+
+func rotZ(angle float64) mat3 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return mat3{{c, s, 0}, {-s, c, 0}, {0, 0, 1}}
+}
+
+// quaternionFromMatrix converts a proper rotation matrix to a unit
+// quaternion representing the same (active) rotation.
+func quaternionFromMatrix(m mat3) models.Quaternion {
+	trace := m[0][0] + m[1][1] + m[2][2]
+
+	var q models.Quaternion
+	switch {
+	case trace > 0:
+		s := 0.5 / math.Sqrt(trace+1)
+		q.W = 0.25 / s
+		q.X = (m[2][1] - m[1][2]) * s
+		q.Y = (m[0][2] - m[2][0]) * s
+		q.Z = (m[1][0] - m[0][1]) * s
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[0][0]-m[1][1]-m[2][2])
+		q.W = (m[2][1] - m[1][2]) / s
+		q.X = 0.25 * s
+		q.Y = (m[0][1] + m[1][0]) / s
+		q.Z = (m[0][2] + m[2][0]) / s
+	case m[1][1] > m[2][2]:
+		s := 2 * math.Sqrt(1+m[1][1]-m[0][0]-m[2][2])
+		q.W = (m[0][2] - m[2][0]) / s
+		q.X = (m[0][1] + m[1][0]) / s
+		q.Y = 0.25 * s
+		q.Z = (m[1][2] + m[2][1]) / s
+	default:
+		s := 2 * math.Sqrt(1+m[2][2]-m[0][0]-m[1][1])
+		q.W = (m[1][0] - m[0][1]) / s
+		q.X = (m[0][2] + m[2][0]) / s
+		q.Y = (m[1][2] + m[2][1]) / s
+		q.Z = 0.25 * s
+	}
+
+	// Keep a canonical sign so consecutive samples don't flip.
+	if q.W < 0 {
+		q.W, q.X, q.Y, q.Z = -q.W, -q.X, -q.Y, -q.Z
+	}
+
+	return q
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidVehicle = errors.New("invalid visiting vehicle")
+
+type VehicleService struct {
+	db *gorm.DB
+}
+
+func NewVehicleService(db *gorm.DB) *VehicleService {
+	return &VehicleService{db: db}
+}
+
+func (s *VehicleService) CreateVehicle(vehicle *models.VisitingVehicle) (*models.VisitingVehicle, error) {
+	if err := s.validateVehicle(0, vehicle); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.Create(vehicle).Error; err != nil {
+		return nil, err
+	}
+	return s.GetVehicleByID(vehicle.ID)
+}
+
+func (s *VehicleService) GetVehicleByID(id uint) (*models.VisitingVehicle, error) {
+	var vehicle models.VisitingVehicle
+	if err := s.db.Preload("Module").First(&vehicle, id).Error; err != nil {
+		return nil, err
+	}
+	return &vehicle, nil
+}
+
+// GetAllVehicles returns every visiting vehicle, most recent arrival first.
+// A non-zero timestamp restricts the list to vehicles attached at that time.
+func (s *VehicleService) GetAllVehicles(timestamp int64) ([]models.VisitingVehicle, error) {
+	query := s.db.Preload("Module")
+	if timestamp != 0 {
+		query = attachedAt(query, time.Unix(timestamp, 0))
+	}
+
+	var vehicles []models.VisitingVehicle
+	if err := query.Order("arrival_at desc").Find(&vehicles).Error; err != nil {
+		return nil, err
+	}
+	return vehicles, nil
+}
+
+func (s *VehicleService) UpdateVehicle(id uint, update *models.VisitingVehicle) (*models.VisitingVehicle, error) {
+	vehicle, err := s.GetVehicleByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateVehicle(id, update); err != nil {
+		return nil, err
+	}
+
+	vehicle.Name = update.Name
+	vehicle.VehicleType = update.VehicleType
+	vehicle.Description = update.Description
+	vehicle.ArrivalAt = update.ArrivalAt
+	vehicle.DepartureAt = update.DepartureAt
+	vehicle.ModuleID = update.ModuleID
+	vehicle.Module = nil
+	vehicle.Port = update.Port
+	vehicle.ModelAssetURL = update.ModelAssetURL
+
+	if err := s.db.Save(vehicle).Error; err != nil {
+		return nil, err
+	}
+	return s.GetVehicleByID(id)
+}
+
+func (s *VehicleService) DeleteVehicle(id uint) error {
+	if err := s.db.Delete(&models.VisitingVehicle{}, id).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPortOccupancy lists every docking port declared in the module catalog
+// together with the vehicle attached to it at timestamp (0 means now).
+// Vehicles on ports missing from the catalog are listed as well.
+func (s *VehicleService) GetPortOccupancy(timestamp int64) (*models.PortOccupancyResponse, error) {
+	if timestamp == 0 {
+		timestamp = time.Now().Unix()
+	}
+
+	var modules []models.Module
+	if err := s.db.Order("name asc").Find(&modules).Error; err != nil {
+		return nil, err
+	}
+
+	var vehicles []models.VisitingVehicle
+	if err := attachedAt(s.db, time.Unix(timestamp, 0)).Find(&vehicles).Error; err != nil {
+		return nil, err
+	}
+
+	attached := make(map[string]*models.VisitingVehicle)
+	for i := range vehicles {
+		attached[portKey(vehicles[i].ModuleID, vehicles[i].Port)] = &vehicles[i]
+	}
+
+	ports := []models.PortOccupancy{}
+	for _, module := range modules {
+		for _, port := range module.DockingPorts {
+			key := portKey(module.ID, port)
+			ports = append(ports, models.PortOccupancy{
+				ModuleID:   module.ID,
+				ModuleName: module.Name,
+				Port:       port,
+				Vehicle:    attached[key],
+			})
+			delete(attached, key)
+		}
+	}
+
+	moduleNames := make(map[uint]string)
+	for _, module := range modules {
+		moduleNames[module.ID] = module.Name
+	}
+	var uncataloged []models.PortOccupancy
+	for _, vehicle := range attached {
+		uncataloged = append(uncataloged, models.PortOccupancy{
+			ModuleID:   vehicle.ModuleID,
+			ModuleName: moduleNames[vehicle.ModuleID],
+			Port:       vehicle.Port,
+			Vehicle:    vehicle,
+		})
+	}
+	slices.SortFunc(uncataloged, func(a, b models.PortOccupancy) int {
+		return strings.Compare(portKey(a.ModuleID, a.Port), portKey(b.ModuleID, b.Port))
+	})
+	ports = append(ports, uncataloged...)
+
+	return &models.PortOccupancyResponse{
+		Timestamp: timestamp,
+		Ports:     ports,
+	}, nil
+}
+
+func (s *VehicleService) validateVehicle(id uint, vehicle *models.VisitingVehicle) error {
+	if vehicle.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidVehicle)
+	}
+	if !slices.Contains(models.VehicleTypes, vehicle.VehicleType) {
+		return fmt.Errorf("%w: vehicle_type must be one of %v", ErrInvalidVehicle, models.VehicleTypes)
+	}
+	if vehicle.ArrivalAt.IsZero() {
+		return fmt.Errorf("%w: arrival_at is required", ErrInvalidVehicle)
+	}
+	if vehicle.DepartureAt != nil && !vehicle.DepartureAt.After(vehicle.ArrivalAt) {
+		return fmt.Errorf("%w: departure_at must be after arrival_at", ErrInvalidVehicle)
+	}
+	if vehicle.Port == "" {
+		return fmt.Errorf("%w: port is required", ErrInvalidVehicle)
+	}
+
+	var module models.Module
+	if err := s.db.First(&module, vehicle.ModuleID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("%w: module %d does not exist", ErrInvalidVehicle, vehicle.ModuleID)
+		}
+		return err
+	}
+	if len(module.DockingPorts) > 0 && !slices.Contains(module.DockingPorts, vehicle.Port) {
+		return fmt.Errorf("%w: module %q has no port %q (ports: %v)",
+			ErrInvalidVehicle, module.Name, vehicle.Port, module.DockingPorts)
+	}
+
+	// The same port can't hold two vehicles at once.
+	query := s.db.Model(&models.VisitingVehicle{}).
+		Where("module_id = ? AND port = ? AND id <> ?", vehicle.ModuleID, vehicle.Port, id).
+		Where("departure_at IS NULL OR departure_at > ?", vehicle.ArrivalAt)
+	if vehicle.DepartureAt != nil {
+		query = query.Where("arrival_at < ?", *vehicle.DepartureAt)
+	}
+
+	var overlapping int64
+	if err := query.Count(&overlapping).Error; err != nil {
+		return err
+	}
+	if overlapping > 0 {
+		return fmt.Errorf("%w: port %q is already occupied during that time", ErrInvalidVehicle, vehicle.Port)
+	}
+
+	return nil
+}
+
+func attachedAt(query *gorm.DB, at time.Time) *gorm.DB {
+	return query.Where("arrival_at <= ? AND (departure_at IS NULL OR departure_at > ?)", at, at)
+}
+
+func portKey(moduleID uint, port string) string {
+	return fmt.Sprintf("%d/%s", moduleID, port)
+}
@@ -0,0 +1,412 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"iss-model-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	WEBHOOK_TIMEOUT          = 10 * time.Second
+	WEBHOOK_DISPATCH_PERIOD  = 2 * time.Second
+	WEBHOOK_BATCH_SIZE       = 50
+	WEBHOOK_CONCURRENCY      = 8
+	WEBHOOK_MAX_ATTEMPTS     = 8
+	WEBHOOK_BASE_BACKOFF     = 10 * time.Second
+	WEBHOOK_MAX_BACKOFF      = time.Hour
+	WEBHOOK_LOG_RETENTION    = 7 * 24 * time.Hour
+	WEBHOOK_SIGNATURE_HEADER = "X-Webhook-Signature"
+	WEBHOOK_TIMESTAMP_HEADER = "X-Webhook-Timestamp"
+	WEBHOOK_EVENT_HEADER     = "X-Webhook-Event"
+	WEBHOOK_DELIVERY_HEADER  = "X-Webhook-Delivery"
+)
+
+var ErrInvalidWebhook = errors.New("invalid webhook")
+
+type WebhookService struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+func NewWebhookService(db *gorm.DB, hub *EventHub) *WebhookService {
+	service := &WebhookService{
+		db:     db,
+		client: &http.Client{Timeout: WEBHOOK_TIMEOUT},
+	}
+
+	hub.OnPublish(service.enqueue)
+
+	go service.startDispatcher()
+
+	return service
+}
+
+func (s *WebhookService) CreateSubscription(subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	if err := validateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	if subscription.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+
+	if err := s.db.Create(subscription).Error; err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) GetSubscriptionByID(id uint) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	if err := s.db.First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (s *WebhookService) GetAllSubscriptions() ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	if err := s.db.Order("created_at desc").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// UpdateSubscription replaces the subscription settings. An empty secret
+// keeps the current one.
+func (s *WebhookService) UpdateSubscription(id uint, update *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	subscription, err := s.GetSubscriptionByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateSubscription(update); err != nil {
+		return nil, err
+	}
+
+	subscription.Name = update.Name
+	subscription.URL = update.URL
+	subscription.EventTypes = update.EventTypes
+	subscription.Active = update.Active
+	if update.Secret != "" {
+		subscription.Secret = update.Secret
+	}
+
+	if err := s.db.Save(subscription).Error; err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *WebhookService) DeleteSubscription(id uint) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.WebhookSubscription{}, id).Error
+	})
+}
+
+// GetDeliveries returns the delivery log of a subscription, newest first.
+// An empty status returns deliveries in any state.
+func (s *WebhookService) GetDeliveries(subscriptionID uint, status string, limit int) ([]models.WebhookDelivery, error) {
+	query := s.db.Where("subscription_id = ?", subscriptionID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var deliveries []models.WebhookDelivery
+	if err := query.Order("created_at desc").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetDeadLetters returns deliveries that exhausted all retry attempts.
+func (s *WebhookService) GetDeadLetters(limit int) ([]models.WebhookDelivery, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+
+	var deliveries []models.WebhookDelivery
+	err := s.db.Where("status = ?", models.WebhookDeliveryDead).
+		Order("updated_at desc").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery puts a dead-lettered delivery back into the queue with a
+// fresh set of attempts.
+func (s *WebhookService) RetryDelivery(id uint) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	if err := s.db.First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+
+	if delivery.Status != models.WebhookDeliveryDead {
+		return nil, fmt.Errorf("%w: only dead-lettered deliveries can be retried", ErrInvalidWebhook)
+	}
+
+	delivery.Status = models.WebhookDeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	if err := s.db.Save(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// enqueue turns a hub event into a pending delivery for each active
+// subscription interested in its type. It runs in the publisher's goroutine,
+// so no event is lost between publishing and the delivery table.
+func (s *WebhookService) enqueue(event models.LiveEvent) {
+	var subscriptions []models.WebhookSubscription
+	if err := s.db.Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		log.Printf("Failed to load webhook subscriptions: %v", err)
+		return
+	}
+
+	var payload []byte
+	for _, subscription := range subscriptions {
+		if !slices.Contains(subscription.EventTypes, event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			var err error
+			if payload, err = json.Marshal(event); err != nil {
+				log.Printf("Failed to encode webhook payload: %v", err)
+				return
+			}
+		}
+
+		delivery := &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         models.WebhookDeliveryPending,
+			NextAttemptAt:  time.Now(),
+		}
+		if err := s.db.Create(delivery).Error; err != nil {
+			log.Printf("Failed to enqueue webhook delivery: %v", err)
+		}
+	}
+}
+
+func (s *WebhookService) startDispatcher() {
+	log.Println("Starting webhook dispatcher...")
+
+	ticker := time.NewTicker(WEBHOOK_DISPATCH_PERIOD)
+	defer ticker.Stop()
+
+	lastCleanup := time.Now()
+
+	for range ticker.C {
+		s.dispatchDue()
+
+		if time.Since(lastCleanup) > time.Hour {
+			s.cleanupDeliveries()
+			lastCleanup = time.Now()
+		}
+	}
+}
+
+func (s *WebhookService) dispatchDue() {
+	var deliveries []models.WebhookDelivery
+	err := s.db.Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at asc").
+		Limit(WEBHOOK_BATCH_SIZE).
+		Find(&deliveries).Error
+	if err != nil {
+		log.Printf("Failed to load due webhook deliveries: %v", err)
+		return
+	}
+
+	subscriptions := make(map[uint]*models.WebhookSubscription)
+
+	// Deliveries go out concurrently so one slow endpoint can't hold up
+	// the others for its full timeout.
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, WEBHOOK_CONCURRENCY)
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = s.GetSubscriptionByID(delivery.SubscriptionID)
+			if err != nil {
+				log.Printf("Dropping webhook delivery %d: %v", delivery.ID, err)
+				s.db.Delete(delivery)
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			s.attemptDelivery(subscription, delivery)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (s *WebhookService) attemptDelivery(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+
+	statusCode, err := s.send(subscription, delivery)
+	delivery.ResponseStatus = statusCode
+
+	if err == nil {
+		now := time.Now()
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &now
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= WEBHOOK_MAX_ATTEMPTS {
+			delivery.Status = models.WebhookDeliveryDead
+			log.Printf("Webhook delivery %d to %s moved to dead letters: %v", delivery.ID, subscription.URL, err)
+		} else {
+			delivery.NextAttemptAt = time.Now().Add(webhookBackoff(delivery.Attempts))
+		}
+	}
+
+	if err := s.db.Save(delivery).Error; err != nil {
+		log.Printf("Failed to update webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+func (s *WebhookService) send(subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "iss-model-backend-webhooks/1.0")
+	req.Header.Set(WEBHOOK_EVENT_HEADER, delivery.EventType)
+	req.Header.Set(WEBHOOK_DELIVERY_HEADER, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WEBHOOK_TIMESTAMP_HEADER, timestamp)
+	req.Header.Set(WEBHOOK_SIGNATURE_HEADER, "sha256="+SignWebhookPayload(subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (s *WebhookService) cleanupDeliveries() {
+	cutoff := time.Now().Add(-WEBHOOK_LOG_RETENTION)
+
+	result := s.db.Where("status = ? AND updated_at < ?", models.WebhookDeliveryDelivered, cutoff).
+		Delete(&models.WebhookDelivery{})
+	if result.Error != nil {
+		log.Printf("Failed to cleanup webhook deliveries: %v", result.Error)
+		return
+	}
+
+	if result.RowsAffected > 0 {
+		log.Printf("Cleaned up %d delivered webhook records", result.RowsAffected)
+	}
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "<timestamp>.<body>".
+// Receivers recompute it with their secret and compare it to the signature
+// header to verify the delivery.
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff doubles the delay with every attempt and adds up to 20%
+// jitter so failing endpoints aren't hit in lockstep.
+func webhookBackoff(attempt int) time.Duration {
+	delay := time.Duration(float64(WEBHOOK_BASE_BACKOFF) * math.Pow(2, float64(attempt-1)))
+	if delay > WEBHOOK_MAX_BACKOFF {
+		delay = WEBHOOK_MAX_BACKOFF
+	}
+	return delay + time.Duration(mathrand.Int64N(int64(delay/5)+1))
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func validateSubscription(subscription *models.WebhookSubscription) error {
+	if subscription.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidWebhook)
+	}
+
+	parsed, err := url.Parse(subscription.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http(s) URL", ErrInvalidWebhook)
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidWebhook)
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !slices.Contains(models.LiveEventTypes, eventType) {
+			return fmt.Errorf("%w: unknown event type %q", ErrInvalidWebhook, eventType)
+		}
+	}
+
+	return nil
+}